@@ -0,0 +1,151 @@
+package base
+
+import (
+	"bytes"
+	"encoding/base32"
+	"errors"
+	"strings"
+
+	"github.com/isarq/nem-sdk-go/external/crypto/sha3"
+)
+
+// A NEM account address, e.g. TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S.
+// An address is the base32 encoding of 25 bytes: the network prefix byte,
+// the 20 bytes RIPEMD-160 of the Keccak-256 of the public key and a 4 bytes checksum,
+// the first bytes of the Keccak-256 of the 21 first bytes.
+// Use ParseAddress to validate an address given by a user.
+type Address string
+
+const (
+	// The length of a plain address
+	AddressLength = 40
+	// The length of a decoded address
+	AddressDecodedLength = 25
+	// The length of the checksum of an address
+	AddressChecksumLength = 4
+)
+
+var (
+	ErrInvalidAddress         = errors.New("invalid address")
+	ErrInvalidAddressChecksum = errors.New("invalid address checksum")
+	ErrUnknownAddressNetwork  = errors.New("unknown address network")
+)
+
+// The network ids of the address prefixes
+var addressNetworks = map[byte]int{
+	0x68: 104,
+	0x98: -104,
+	0x60: 96,
+}
+
+// Gets the address prefix of a network
+// param network - A network id
+// return - The network prefix, the Mijin prefix for an unknown network
+func NetworkPrefix(network int) byte {
+	for prefix, id := range addressNetworks {
+		if id == network {
+			return prefix
+		}
+	}
+	return 0x60
+}
+
+// Parse an address in plain or pretty form, the case and the dashes are ignored
+// param s - An address, e.g. TBCI2A-67UQZA-KCR6NS-4JWAEI-CEIGEI-M72G3M-VW5S
+// return - The plain address, an error when the address is not valid
+func ParseAddress(s string) (Address, error) {
+	address := Address(s).Plain()
+	if err := address.Validate(); err != nil {
+		return "", err
+	}
+	return address, nil
+}
+
+// Same as ParseAddress, it panics when the address is not valid.
+// It is meant for the constant addresses of a program.
+func MustParseAddress(s string) Address {
+	address, err := ParseAddress(s)
+	if err != nil {
+		panic(err.Error() + ": " + s)
+	}
+	return address
+}
+
+// Gets the plain form of the address: upper case, without dashes nor spaces
+func (a Address) Plain() Address {
+	s := strings.Replace(strings.TrimSpace(string(a)), "-", "", -1)
+	return Address(strings.ToUpper(strings.Replace(s, " ", "", -1)))
+}
+
+// Gets the plain form of the address, as expected by NIS
+func (a Address) String() string {
+	return string(a.Plain())
+}
+
+// Gets the pretty form of the address, groups of 6 characters separated by dashes
+// return - e.g. TBCI2A-67UQZA-KCR6NS-4JWAEI-CEIGEI-M72G3M-VW5S
+func (a Address) Pretty() string {
+	s := a.String()
+	var b strings.Builder
+	for i := 0; i < len(s); i += 6 {
+		if i > 0 {
+			b.WriteByte('-')
+		}
+		end := i + 6
+		if end > len(s) {
+			end = len(s)
+		}
+		b.WriteString(s[i:end])
+	}
+	return b.String()
+}
+
+// Check the address: its length, its base32 encoding, its checksum and its network prefix
+// return - ErrInvalidAddress, ErrInvalidAddressChecksum or ErrUnknownAddressNetwork, nil when valid
+func (a Address) Validate() error {
+	decoded, err := a.decode()
+	if err != nil {
+		return err
+	}
+	sum := sha3.SumKeccak256(decoded[:AddressDecodedLength-AddressChecksumLength])
+	if !bytes.Equal(sum[:AddressChecksumLength], decoded[AddressDecodedLength-AddressChecksumLength:]) {
+		return ErrInvalidAddressChecksum
+	}
+	if _, ok := addressNetworks[decoded[0]]; !ok {
+		return ErrUnknownAddressNetwork
+	}
+	return nil
+}
+
+// Check the validity of the address
+func (a Address) IsValid() bool {
+	return a.Validate() == nil
+}
+
+// Gets the network id of the address from its prefix byte
+// return - 104 (mainnet), -104 (testnet), 96 (mijin), 0 when the address is not valid
+func (a Address) Network() int {
+	if a.Validate() != nil {
+		return 0
+	}
+	decoded, _ := a.decode()
+	return addressNetworks[decoded[0]]
+}
+
+// Check if the address is a valid address of a network
+// param network - A network id
+func (a Address) IsFromNetwork(network int) bool {
+	return network != 0 && a.Network() == network
+}
+
+func (a Address) decode() ([]byte, error) {
+	s := a.String()
+	if len(s) != AddressLength {
+		return nil, ErrInvalidAddress
+	}
+	decoded, err := base32.StdEncoding.DecodeString(s)
+	if err != nil || len(decoded) != AddressDecodedLength {
+		return nil, ErrInvalidAddress
+	}
+	return decoded, nil
+}
@@ -0,0 +1,62 @@
+package base_test
+
+import (
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+func TestParseAddress(t *testing.T) {
+	for network, sink := range model.Apostille {
+		address, err := base.ParseAddress(sink)
+		if err != nil {
+			t.Fatalf("ParseAddress(%s): %v", sink, err)
+		}
+		if address.Network() != network {
+			t.Errorf("%s: Network() = %d, want %d", sink, address.Network(), network)
+		}
+		if address.Pretty() != sink {
+			t.Errorf("Pretty() = %s, want %s", address.Pretty(), sink)
+		}
+		lower, err := base.ParseAddress(" " + string(address.Plain()[:20]) + "-" + string(address.Plain()[20:]) + " ")
+		if err != nil || lower != address {
+			t.Errorf("ParseAddress of a reformatted %s = %s, %v", sink, lower, err)
+		}
+	}
+
+	valid := base.Address(model.Mosaic[model.Data.Testnet.ID]).String()
+	for _, tc := range []struct {
+		address string
+		err     error
+	}{
+		{"", base.ErrInvalidAddress},
+		{valid[:39], base.ErrInvalidAddress},
+		{valid[:39] + "1", base.ErrInvalidAddress},
+		{valid[:39] + string(valid[39]^1), base.ErrInvalidAddressChecksum},
+	} {
+		if _, err := base.ParseAddress(tc.address); err != tc.err {
+			t.Errorf("ParseAddress(%q) = %v, want %v", tc.address, err, tc.err)
+		}
+	}
+}
+
+func TestPubToAddress(t *testing.T) {
+	const publicKey = "0257b05f601ff829fdff84956fb5e3c65470a62375a1cc285779edd5ca3b42f6"
+	for _, network := range []int{model.Data.Testnet.ID, model.Data.Mainnet.ID, model.Data.Mijin.ID} {
+		address, err := utils.PubToAddress(publicKey, network)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := address.Validate(); err != nil {
+			t.Errorf("%s: %v", address, err)
+		}
+		if !address.IsFromNetwork(network) {
+			t.Errorf("%s is not from network %d", address, network)
+		}
+		if string(address[:1]) != model.Id2Char(network) {
+			t.Errorf("%s does not start with %s", address, model.Id2Char(network))
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package base
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// An exact amount of an asset in its smallest unit: micro-XEM for XEM,
+// or the smallest divisible unit of a mosaic.
+type Amount uint64
+
+// A mosaic quantity in the smallest divisible unit of the mosaic
+type Quantity = Amount
+
+const (
+	// The smallest unit of XEM
+	MicroXEM Amount = 1
+	// One XEM
+	XEM Amount = 1000000
+	// The divisibility of XEM
+	XEMDivisibility = 6
+	// The maximum divisibility of a mosaic
+	MaxDivisibility = 6
+)
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// Parse a decimal amount with the given divisibility
+// The decimal separator may be a dot or a comma, e.g. "1.5" is 1500000 with a divisibility of 6
+// param s - The decimal amount
+// param divisibility - The divisibility of the asset (6 for XEM)
+// return - The amount in the smallest unit of the asset
+func ParseAmount(s string, divisibility int) (Amount, error) {
+	if divisibility < 0 || divisibility > MaxDivisibility {
+		return 0, ErrInvalidAmount
+	}
+	s = strings.Replace(strings.TrimSpace(s), ",", ".", 1)
+	whole, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, fraction = s[:i], s[i+1:]
+	}
+	if whole == "" && fraction == "" {
+		return 0, ErrInvalidAmount
+	}
+	// Extra decimals are only allowed when they are zeros
+	if len(fraction) > divisibility {
+		if strings.Trim(fraction[divisibility:], "0") != "" {
+			return 0, ErrInvalidAmount
+		}
+		fraction = fraction[:divisibility]
+	}
+	digits := whole + fraction + strings.Repeat("0", divisibility-len(fraction))
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return 0, ErrInvalidAmount
+		}
+	}
+	v, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	return Amount(v), nil
+}
+
+// Format an amount as a decimal with the given divisibility
+// param divisibility - The divisibility of the asset (6 for XEM)
+// return - The decimal amount, e.g. "1.500000" for 1500000 with a divisibility of 6
+func (a Amount) Format(divisibility int) string {
+	s := strconv.FormatUint(uint64(a), 10)
+	if divisibility <= 0 {
+		return s
+	}
+	if len(s) <= divisibility {
+		s = strings.Repeat("0", divisibility-len(s)+1) + s
+	}
+	return s[:len(s)-divisibility] + "." + s[len(s)-divisibility:]
+}
+
+// Decode an amount from a JSON number or a string holding an integer
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" || s == "" {
+		return nil
+	}
+	if v, err := strconv.ParseUint(s, 10, 64); err == nil {
+		*a = Amount(v)
+		return nil
+	}
+	// Exponent notation, only accepted when it is an exact integer
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 || f != math.Trunc(f) || f >= 1<<53 {
+		return ErrInvalidAmount
+	}
+	*a = Amount(f)
+	return nil
+}
@@ -26,7 +26,7 @@ type Error struct {
 // This is done via a mosaic definition creation transaction.
 type MosaicDefinitionCreationTransaction struct {
 	CommonTransaction
-	CreationFee      float64          `json:"creationFee"`
+	CreationFee      Amount           `json:"creationFee"`
 	CreationFeeSink  string           `json:"creationFeeSink"`
 	MosaicDefinition MosaicDefinition `json:"mosaicDefinition"`
 }
@@ -61,7 +61,7 @@ func (m MosaicID) String() string {
 // Mosaics can be transferred by means of a transfer transaction.
 type Mosaic struct {
 	MosaicID MosaicID `json:"mosaicID,omitempty"`
-	Quantity float64  `json:"quantity,omitempty"`
+	Quantity Quantity `json:"quantity,omitempty"`
 }
 
 type Properties struct {
@@ -73,7 +73,18 @@ type Levy struct {
 	Type      int      `json:"type,omitempty"`
 	Recipient string   `json:"recipient,omitempty"`
 	MosaicID  MosaicID `json:"mosaicId,omitempty"`
-	Fee       float64  `json:"fee,omitempty"`
+	Fee       Amount   `json:"fee,omitempty"`
+}
+
+// The levy paid by the sender of a transfer for an attached mosaic.
+// It is not part of the serialized transaction, NIS applies it on inclusion.
+type MosaicLevy struct {
+	// Mosaic is the attached mosaic
+	Mosaic MosaicID `json:"mosaic"`
+	// Levy is the levy of the mosaic definition
+	Levy Levy `json:"levy"`
+	// Quantity is the quantity of the levy mosaic paid to the levy recipient
+	Quantity Quantity `json:"quantity"`
 }
 
 type Node struct {
@@ -87,17 +98,19 @@ type MessageType struct {
 }
 
 type ConsModif struct {
-	ModificationType   int
-	CosignatoryAccount string
+	ModificationType   int    `json:"modificationType"`
+	CosignatoryAccount string `json:"cosignatoryAccount"`
 }
 
 type TransferTransaction struct {
 	CommonTransaction
-	Amount    float64  `json:"amount,omitempty"`
+	Amount    Amount   `json:"amount,omitempty"`
 	Recipient string   `json:"recipient,omitempty"`
 	Message   Message  `json:"message,omitempty"`
 	Signature string   `json:"signature,omitempty"`
 	Mosaics   []Mosaic `json:"mosaics,omitempty"`
+	// Levies are the levies of the attached mosaics, set by Transfer.PrepareMosaic
+	Levies []MosaicLevy `json:"levies,omitempty"`
 }
 
 type Common struct {
@@ -121,6 +134,7 @@ type Message struct {
 	PublicKey string `json:"publicKey,omitempty"`
 }
 
+// Deprecated: SignatureT is not used anymore, use transactions.MultisigSignature.
 type SignatureT struct {
 	OtherHash struct {
 		Data string
@@ -128,6 +142,7 @@ type SignatureT struct {
 	OtherAccount string
 }
 
+// Deprecated: Supply is not used anymore, use transactions.MosaicSupply.
 type Supply struct {
 	Mosaic          string `json:"mosaic"`
 	SupplyType      int    `json:"supplyType"`
@@ -136,8 +151,24 @@ type Supply struct {
 	MultisigAccount string `json:"multisigAccount"`
 }
 
+// Deprecated: MultisigAggregateModific is not used anymore, use transactions.MultisigAggregateModification.
+type MultisigAggregateModific struct {
+	Modifications   []interface{} `json:"modifications"`
+	RelativeChange  interface{}   `json:"relativeChange"`
+	IsMultisig      bool          `json:"isMultisig"`
+	MultisigAccount string        `json:"multisigAccount"`
+}
+
+// Deprecated: ImportanceTransfer is not used anymore, use transactions.ImportanceTransfer.
+type ImportanceTransfer struct {
+	RemoteAccount   string `json:"remoteAccount"`
+	Mode            int    `json:"mode"`
+	IsMultisig      bool   `json:"isMultisig"`
+	MultisigAccount string `json:"multisigAccount"`
+}
+
 type MosaicsData struct {
-	Quantity int      `json:"quantity"`
+	Quantity Quantity `json:"quantity"`
 	MosaicID MosaicID `json:"mosaicId"`
 }
 
@@ -152,26 +183,12 @@ func (m *MosaicsData) String() string {
 	)
 }
 
-type MultisigAggregateModific struct {
-	Modifications   []interface{} `json:"modifications"`
-	RelativeChange  interface{}   `json:"relativeChange"`
-	IsMultisig      bool          `json:"isMultisig"`
-	MultisigAccount string        `json:"multisigAccount"`
-}
-
-type ImportanceTransfer struct {
-	RemoteAccount   string `json:"remoteAccount"`
-	Mode            int    `json:"mode"`
-	IsMultisig      bool   `json:"isMultisig"`
-	MultisigAccount string `json:"multisigAccount"`
-}
-
 type CommonTransaction struct {
 	Type      int
 	Version   int
 	Signer    string
 	TimeStamp *int64
-	Fee       float64
+	Fee       Amount
 	Deadline  *int64
 }
 
@@ -195,15 +212,15 @@ func (c *CommonTransaction) String() string {
 
 type ProvisionNamespaceTransaction struct {
 	CommonTransaction
-	RentalFeeSink string  `json:"rentalFeeSink"`
-	RentalFee     float64 `json:"rentalFee"`
-	NewPart       string  `json:"newPart"`
-	Parent        string  `json:"parent"`
+	RentalFeeSink string `json:"rentalFeeSink"`
+	RentalFee     Amount `json:"rentalFee"`
+	NewPart       string `json:"newPart"`
+	Parent        string `json:"parent"`
 }
 
 type MultiSignSignatureTransaction struct {
 	TimeStamp int64  `json:"timeStamp"`
-	Fee       int    `json:"fee"`
+	Fee       Amount `json:"fee"`
 	Type      int    `json:"type"`
 	Deadline  int64  `json:"deadline"`
 	Version   int    `json:"version"`
@@ -216,8 +233,8 @@ type MultiSignSignatureTransaction struct {
 
 type TransactionResponse struct {
 	TimeStamp  int64                           `json:"timeStamp"`
-	Amount     float64                         `json:"amount"`
-	Fee        float64                         `json:"fee"`
+	Amount     Amount                          `json:"amount"`
+	Fee        Amount                          `json:"fee"`
 	Recipient  string                          `json:"recipient,omitempty"`
 	Type       int                             `json:"type,omitempty"`
 	Deadline   int64                           `json:"deadline"`
@@ -237,8 +254,8 @@ type MultiSignTransaction struct {
 
 type AbstractTransaction struct {
 	TimeStamp int64    `json:"timeStamp,omitempty"`
-	Amount    float64  `json:"amount,omitempty"`
-	Fee       float64  `json:"fee,omitempty"`
+	Amount    Amount   `json:"amount,omitempty"`
+	Fee       Amount   `json:"fee,omitempty"`
 	Recipient string   `json:"recipient,omitempty"`
 	Type      int      `json:"type,omitempty"`
 	Deadline  int64    `json:"deadline,omitempty"`
@@ -443,3 +460,172 @@ func (t *TransferTransaction) String() string {
 func (t *TransferTransaction) GetTx() Transaction {
 	return t
 }
+
+// Mosaic supply change transactions increase or decrease the supply of
+// a mosaic the signer created.
+type MosaicSupplyChangeTransaction struct {
+	CommonTransaction
+	MosaicID   MosaicID `json:"mosaicId"`
+	SupplyType int      `json:"supplyType"`
+	Delta      uint64   `json:"delta"`
+}
+
+func (t *MosaicSupplyChangeTransaction) GetType() int {
+	return t.Type
+}
+
+func (t *MosaicSupplyChangeTransaction) GetCommon() *CommonTransaction {
+	return &CommonTransaction{
+		Type:      t.Type,
+		Version:   t.Version,
+		TimeStamp: t.TimeStamp,
+		Deadline:  t.Deadline,
+		Signer:    t.Signer,
+		Fee:       t.Fee,
+	}
+}
+
+func (t *MosaicSupplyChangeTransaction) String() string {
+	return fmt.Sprintf(
+		`
+			"Common": %v,
+			"MosaicID": %v,
+			"SupplyType": %v,
+			"Delta": %v
+		`,
+		t.CommonTransaction.String(),
+		t.MosaicID,
+		t.SupplyType,
+		t.Delta,
+	)
+}
+
+func (t *MosaicSupplyChangeTransaction) GetTx() Transaction {
+	return t
+}
+
+// Importance transfer transactions delegate the importance of an account
+// to a remote account used for harvesting.
+type ImportanceTransferTransaction struct {
+	CommonTransaction
+	Mode          int    `json:"mode"`
+	RemoteAccount string `json:"remoteAccount"`
+}
+
+func (t *ImportanceTransferTransaction) GetType() int {
+	return t.Type
+}
+
+func (t *ImportanceTransferTransaction) GetCommon() *CommonTransaction {
+	return &CommonTransaction{
+		Type:      t.Type,
+		Version:   t.Version,
+		TimeStamp: t.TimeStamp,
+		Deadline:  t.Deadline,
+		Signer:    t.Signer,
+		Fee:       t.Fee,
+	}
+}
+
+func (t *ImportanceTransferTransaction) String() string {
+	return fmt.Sprintf(
+		`
+			"Common": %v,
+			"Mode": %v,
+			"RemoteAccount": %v
+		`,
+		t.CommonTransaction.String(),
+		t.Mode,
+		t.RemoteAccount,
+	)
+}
+
+func (t *ImportanceTransferTransaction) GetTx() Transaction {
+	return t
+}
+
+type MinCosignatories struct {
+	RelativeChange int `json:"relativeChange"`
+}
+
+// Multisig aggregate modification transactions convert an account to a
+// multisig account or change its cosignatories and minimum of cosignatories.
+type MultisigAggregateModificationTransaction struct {
+	CommonTransaction
+	Modifications    []ConsModif       `json:"modifications"`
+	MinCosignatories *MinCosignatories `json:"minCosignatories,omitempty"`
+}
+
+func (t *MultisigAggregateModificationTransaction) GetType() int {
+	return t.Type
+}
+
+func (t *MultisigAggregateModificationTransaction) GetCommon() *CommonTransaction {
+	return &CommonTransaction{
+		Type:      t.Type,
+		Version:   t.Version,
+		TimeStamp: t.TimeStamp,
+		Deadline:  t.Deadline,
+		Signer:    t.Signer,
+		Fee:       t.Fee,
+	}
+}
+
+func (t *MultisigAggregateModificationTransaction) String() string {
+	return fmt.Sprintf(
+		`
+			"Common": %v,
+			"Modifications": %v,
+			"MinCosignatories": %v
+		`,
+		t.CommonTransaction.String(),
+		t.Modifications,
+		t.MinCosignatories,
+	)
+}
+
+func (t *MultisigAggregateModificationTransaction) GetTx() Transaction {
+	return t
+}
+
+// Multisig signature transactions are used by cosignatories to sign a
+// pending multisig transaction.
+type MultisigSignatureTransaction struct {
+	CommonTransaction
+	OtherHash struct {
+		Data string `json:"data"`
+	} `json:"otherHash"`
+	OtherAccount string `json:"otherAccount"`
+}
+
+func (t *MultisigSignatureTransaction) GetType() int {
+	return t.Type
+}
+
+func (t *MultisigSignatureTransaction) GetCommon() *CommonTransaction {
+	return &CommonTransaction{
+		Type:      t.Type,
+		Version:   t.Version,
+		TimeStamp: t.TimeStamp,
+		Deadline:  t.Deadline,
+		Signer:    t.Signer,
+		Fee:       t.Fee,
+	}
+}
+
+func (t *MultisigSignatureTransaction) String() string {
+	return fmt.Sprintf(
+		`
+			"Common": %v,
+			"OtherHash": %v,
+			"OtherAccount": %v
+		`,
+		t.CommonTransaction.String(),
+		t.OtherHash.Data,
+		t.OtherAccount,
+	)
+}
+
+func (t *MultisigSignatureTransaction) GetTx() Transaction {
+	return t
+}
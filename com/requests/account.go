@@ -2,16 +2,15 @@ package requests
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/isarq/nem-sdk-go/base"
 	"github.com/isarq/nem-sdk-go/model"
-	"io"
-	"io/ioutil"
 	"net/http"
+	"strconv"
 	"sync"
-	"time"
 )
 
 // AccountInfo describes basic information for an account.
@@ -19,9 +18,9 @@ type AccountInfo struct {
 	// Address contains the address of the account.
 	Address string
 	// Balance contains the balance of the account in micro NEM.
-	Balance float64
+	Balance base.Amount
 	// vestedBalance contains the vested part of the balance of the account in micro NEM.
-	VestedBalance float64
+	VestedBalance base.Amount
 	// Importance contains the importance of the account.
 	Importance float64
 	// PublicKey contains the public key of the account.
@@ -30,6 +29,16 @@ type AccountInfo struct {
 	Label string
 	// HarvestedBlocks contains the number of blocks that the account already harvested.
 	HarvestedBlocks int
+	// MultisigInfo contains the multisig settings of the account (empty if not multisig).
+	MultisigInfo MultisigInfo
+}
+
+// MultisigInfo describes the cosignatories settings of a multisig account.
+type MultisigInfo struct {
+	// CosignatoriesCount contains the number of cosignatories of the account.
+	CosignatoriesCount int `json:"cosignatoriesCount"`
+	// MinCosignatories contains the number of cosignatures required (0 means all of them).
+	MinCosignatories int `json:"minCosignatories"`
 }
 
 // AccountMetaData describes additional information for the account.
@@ -70,7 +79,7 @@ type AccountMetaDataPair struct {
 type HarvestInfo struct {
 	TimeStamp  int64
 	Difficulty int
-	TotalFee   int
+	TotalFee   base.Amount
 	ID         int
 	Height     int64
 }
@@ -93,12 +102,28 @@ type TransactionMetaDataPair struct {
 	Transaction base.Transaction    `json:"transaction"`
 }
 
+// Decode the transaction by its type (see MapTransaction)
+func (p *TransactionMetaDataPair) UnmarshalJSON(data []byte) error {
+	meta, tx, err := MapTransaction(bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	p.Meta, p.Transaction = *meta, tx
+	return nil
+}
+
 // The unconfirmed transaction meta data contains the hash of the inner transaction in case the transaction
 // is a multisig transaction. This data is need to initiate a multisig signature transaction.
 type MetaData struct {
 	Data string `json:"data"`
 }
 
+// An unconfirmed transaction with its meta data.
+type UnconfirmedTransactionMetaDataPair struct {
+	Meta        MetaData         `json:"meta"`
+	Transaction base.Transaction `json:"transaction"`
+}
+
 type unconfirmedMosaicTransactionMetaDataPair struct {
 	Meta        TransactionMetaData    `json:"meta"`
 	Transaction base.TransactionMosaic `json:"transaction"`
@@ -108,39 +133,23 @@ func (t *unconfirmedMosaicTransactionMetaDataPair) toStruct() (*TransactionMetaD
 	return &t.Meta, &t.Transaction, nil
 }
 
-type multiSignTransactionMetaDataPair struct {
-	Meta        TransactionMetaData       `json:"meta"`
-	Transaction base.MultiSignTransaction `json:"transaction"`
+// A transaction with its meta data, the transaction is decoded by its type
+type transactionMetaDataPair struct {
+	Meta        TransactionMetaData `json:"meta"`
+	Transaction json.RawMessage     `json:"transaction"`
 }
 
-func (t *multiSignTransactionMetaDataPair) toStruct() (*TransactionMetaData, base.Transaction, error) {
-
-	p := t.Transaction.OtherTrans
-	d, err := json.Marshal(p)
+func (t *transactionMetaDataPair) toStruct() (*TransactionMetaData, base.Transaction, error) {
+	tx, err := mapOtherTransaction(bytes.NewBuffer(t.Transaction))
 	if err != nil {
 		return nil, nil, err
 	}
-
-	var m json.RawMessage
-
-	err = json.Unmarshal(d, &m)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	other, err := mapOtherTransaction(bytes.NewBuffer(m))
-	if err != nil {
-		return nil, nil, err
-	}
-
-	t.Transaction.OtherTrans = other
-
-	return &t.Meta, &t.Transaction, nil
+	return &t.Meta, tx, nil
 }
 
 type transferTransaction struct {
 	base.CommonTransaction
-	Amount    float64       `json:"amount,omitempty"`
+	Amount    base.Amount   `json:"amount,omitempty"`
 	Recipient string        `json:"recipient,omitempty"`
 	Message   base.Message  `json:"message,omitempty"`
 	Signature string        `json:"signature,omitempty"`
@@ -165,6 +174,68 @@ func (t *transferTransaction) toStruct() (base.Transaction, error) {
 	}, nil
 }
 
+type importanceTransferTransaction base.ImportanceTransferTransaction
+
+func (t *importanceTransferTransaction) toStruct() (base.Transaction, error) {
+	tx := base.ImportanceTransferTransaction(*t)
+	return &tx, nil
+}
+
+type multisigModificationTransaction base.MultisigAggregateModificationTransaction
+
+func (t *multisigModificationTransaction) toStruct() (base.Transaction, error) {
+	tx := base.MultisigAggregateModificationTransaction(*t)
+	return &tx, nil
+}
+
+type multisigSignatureTransaction base.MultisigSignatureTransaction
+
+func (t *multisigSignatureTransaction) toStruct() (base.Transaction, error) {
+	tx := base.MultisigSignatureTransaction(*t)
+	return &tx, nil
+}
+
+type provisionNamespaceTransaction base.ProvisionNamespaceTransaction
+
+func (t *provisionNamespaceTransaction) toStruct() (base.Transaction, error) {
+	tx := base.ProvisionNamespaceTransaction(*t)
+	return &tx, nil
+}
+
+type mosaicDefinitionTransaction base.MosaicDefinitionCreationTransaction
+
+func (t *mosaicDefinitionTransaction) toStruct() (base.Transaction, error) {
+	tx := base.MosaicDefinitionCreationTransaction(*t)
+	return &tx, nil
+}
+
+type mosaicSupplyTransaction base.MosaicSupplyChangeTransaction
+
+func (t *mosaicSupplyTransaction) toStruct() (base.Transaction, error) {
+	tx := base.MosaicSupplyChangeTransaction(*t)
+	return &tx, nil
+}
+
+// A multisig transaction, the inner transaction is decoded by its type
+// and the cosignatures are kept in Signatures
+type multiSignTransaction struct {
+	base.CommonTransaction
+	OtherTrans json.RawMessage                      `json:"otherTrans"`
+	Signatures []base.MultiSignSignatureTransaction `json:"signatures,omitempty"`
+}
+
+func (t *multiSignTransaction) toStruct() (base.Transaction, error) {
+	other, err := mapOtherTransaction(bytes.NewBuffer(t.OtherTrans))
+	if err != nil {
+		return nil, err
+	}
+	return &base.MultiSignTransaction{
+		CommonTransaction: t.CommonTransaction,
+		OtherTrans:        other,
+		Signatures:        t.Signatures,
+	}, nil
+}
+
 // Each node can allow users to harvest with their delegated key on that node.
 // The NIS configuration has entries for configuring the maximum number of allowed harvesters and optionally allow
 // harvesting only for certain account addresses.
@@ -197,12 +268,12 @@ func MapTransactions(b *bytes.Buffer) ([]base.Transaction, error) {
 	var wg sync.WaitGroup
 	var err error
 
-	var m []json.RawMessage
-
-	err = json.Unmarshal(b.Bytes()[8:len(b.Bytes())-3], &m)
+	var data = struct{ Data []json.RawMessage }{}
+	err = json.Unmarshal(b.Bytes(), &data)
 	if err != nil {
 		return nil, err
 	}
+	m := data.Data
 
 	txs := make([]base.Transaction, len(m))
 	meta := make([]*TransactionMetaData, len(m))
@@ -251,20 +322,34 @@ func MapTransaction(b *bytes.Buffer) (*TransactionMetaData, base.Transaction, er
 		t = rawT.Type
 	}
 
+	// A transaction without meta data
+	if rawT.Transaction.Type == 0 {
+		tx, err := mapOtherTransaction(b)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &TransactionMetaData{}, tx, nil
+	}
+
 	var dto transactionDto = nil
 
 	switch t {
 	case model.Transfer:
 		dto = &unconfirmedMosaicTransactionMetaDataPair{}
-	case model.MultiSignTransaction:
-		dto = &multiSignTransactionMetaDataPair{}
+	case model.MultiSignTransaction, model.ImportanceTransfer, model.MultisigModification, model.MultiSignSignature,
+		model.ProvisionNamespace, model.MosaicDefinition, model.MosaicSupply:
+		dto = &transactionMetaDataPair{}
 	default:
-		fmt.Println(rawT.Transaction.Type)
+		return nil, nil, unknownType(t)
 	}
 
 	return dtoToTransaction(b, dto)
 }
 
+func unknownType(t uint16) error {
+	return errors.New("unknown transaction type " + strconv.Itoa(int(t)))
+}
+
 func mapOtherTransaction(b *bytes.Buffer) (base.Transaction, error) {
 
 	rawT := struct {
@@ -281,8 +366,22 @@ func mapOtherTransaction(b *bytes.Buffer) (base.Transaction, error) {
 	switch rawT.Type {
 	case model.Transfer:
 		dto = &transferTransaction{}
+	case model.ImportanceTransfer:
+		dto = &importanceTransferTransaction{}
+	case model.MultisigModification:
+		dto = &multisigModificationTransaction{}
+	case model.MultiSignSignature:
+		dto = &multisigSignatureTransaction{}
+	case model.MultiSignTransaction:
+		dto = &multiSignTransaction{}
+	case model.ProvisionNamespace:
+		dto = &provisionNamespaceTransaction{}
+	case model.MosaicDefinition:
+		dto = &mosaicDefinitionTransaction{}
+	case model.MosaicSupply:
+		dto = &mosaicSupplyTransaction{}
 	default:
-		fmt.Println(rawT.Type)
+		return nil, unknownType(rawT.Type)
 	}
 
 	return dtoToOtherTransaction(b, dto)
@@ -332,30 +431,23 @@ func dtoToOtherTransaction(b *bytes.Buffer, dto otherTransactionDto) (base.Trans
 
 // Gets the AccountMetaDataPair of an account.
 // method Client - An Client endpoint struct point
-// param {string} address - An account address
+// param address - An account address, see base.ParseAddress
 // return {struct} - An struct[AccountMetaDataPair] struct
 // link http://bob.nem.ninja/docs/#accountMetaDataPair
-func (c *Client) AccountData(address string) (AccountMetaDataPair, error) {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/account/get"
-	req, err := c.buildReq(map[string]string{"address": address}, nil, http.MethodGet)
+func (c *Client) AccountData(address base.Address) (AccountMetaDataPair, error) {
+	return c.AccountDataCtx(context.Background(), address)
+}
+
+// Same as AccountData, the request is bound to ctx
+func (c *Client) AccountDataCtx(ctx context.Context, address base.Address) (AccountMetaDataPair, error) {
+	req, err := c.buildReq("/account/get", map[string]string{"address": address.String()}, nil, http.MethodGet)
 	if err != nil {
 		return AccountMetaDataPair{}, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return AccountMetaDataPair{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return AccountMetaDataPair{}, err
-	}
 
 	var data AccountMetaDataPair
 	if err := json.Unmarshal(byteArray, &data); err != nil {
@@ -371,26 +463,19 @@ func (c *Client) AccountData(address string) (AccountMetaDataPair, error) {
 // return - An struct [AccountMetaDataPair] struct
 // link http://bob.nem.ninja/docs/#accountMetaDataPair
 func (c *Client) AccountDataFromPublicKey(publicKey string) (AccountMetaDataPair, error) {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/account/get/from-public-key"
-	req, err := c.buildReq(map[string]string{"publicKey": publicKey}, nil, http.MethodGet)
+	return c.AccountDataFromPublicKeyCtx(context.Background(), publicKey)
+}
+
+// Same as AccountDataFromPublicKey, the request is bound to ctx
+func (c *Client) AccountDataFromPublicKeyCtx(ctx context.Context, publicKey string) (AccountMetaDataPair, error) {
+	req, err := c.buildReq("/account/get/from-public-key", map[string]string{"publicKey": publicKey}, nil, http.MethodGet)
 	if err != nil {
 		return AccountMetaDataPair{}, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return AccountMetaDataPair{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return AccountMetaDataPair{}, err
-	}
 
 	var data AccountMetaDataPair
 	if err := json.Unmarshal(byteArray, &data); err != nil {
@@ -402,30 +487,23 @@ func (c *Client) AccountDataFromPublicKey(publicKey string) (AccountMetaDataPair
 
 // Gets an array of harvest info objects for an account.
 // method Client - An Client endpoint struct point
-// param address - An account address
+// param address - An account address, see base.ParseAddress
 // return - An slice of [HarvestInfo] struct
 // link http://bob.nem.ninja/docs/#harvestInfo
-func (c *Client) HarvestedBlocks(address string) ([]HarvestInfo, error) {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/account/harvests"
-	req, err := c.buildReq(map[string]string{"address": address}, nil, http.MethodGet)
+func (c *Client) HarvestedBlocks(address base.Address) ([]HarvestInfo, error) {
+	return c.HarvestedBlocksCtx(context.Background(), address)
+}
+
+// Same as HarvestedBlocks, the request is bound to ctx
+func (c *Client) HarvestedBlocksCtx(ctx context.Context, address base.Address) ([]HarvestInfo, error) {
+	req, err := c.buildReq("/account/harvests", map[string]string{"address": address.String()}, nil, http.MethodGet)
 	if err != nil {
 		return []HarvestInfo{}, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return []HarvestInfo{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []HarvestInfo{}, err
-	}
 
 	var data = struct{ Data []HarvestInfo }{}
 	if err := json.Unmarshal(byteArray, &data); err != nil {
@@ -436,17 +514,18 @@ func (c *Client) HarvestedBlocks(address string) ([]HarvestInfo, error) {
 
 // Gets an array of TransactionMetaDataPair objects where the recipient has the address given as parameter to the request.
 // method Client - An Client endpoint struct point
-// param address - An account address
+// param address - An account address, see base.ParseAddress
 // param txHash - The 256 bit sha3 hash of the transaction up to which transactions are returned. (optional)
 // param txId - The transaction id up to which transactions are returned. (optional)
 // return - An slice of [TransactionMetaDataPair] struct
 // link http://bob.nem.ninja/docs/#transactionMetaDataPair}
-func (c *Client) IncomingTransactions(address, txHash, txId string) ([]TransactionMetaDataPair, error) {
-	params := map[string]string{"address": address}
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
+func (c *Client) IncomingTransactions(address base.Address, txHash, txId string) ([]TransactionMetaDataPair, error) {
+	return c.IncomingTransactionsCtx(context.Background(), address, txHash, txId)
+}
+
+// Same as IncomingTransactions, the request is bound to ctx
+func (c *Client) IncomingTransactionsCtx(ctx context.Context, address base.Address, txHash, txId string) ([]TransactionMetaDataPair, error) {
+	params := map[string]string{"address": address.String()}
 	if txHash != "" {
 		params["hash"] = txHash
 	}
@@ -454,22 +533,14 @@ func (c *Client) IncomingTransactions(address, txHash, txId string) ([]Transacti
 		params["id"] = txId
 	}
 
-	c.URL.Path = "/account/transfers/incoming"
-	req, err := c.buildReq(params, nil, http.MethodGet)
+	req, err := c.buildReq("/account/transfers/incoming", params, nil, http.MethodGet)
 	if err != nil {
 		return []TransactionMetaDataPair{}, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return []TransactionMetaDataPair{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []TransactionMetaDataPair{}, err
-	}
 
 	var data = struct{ Data []TransactionMetaDataPair }{}
 	if err := json.Unmarshal(byteArray, &data); err != nil {
@@ -481,17 +552,18 @@ func (c *Client) IncomingTransactions(address, txHash, txId string) ([]Transacti
 
 // Gets an array of TransactionMetaDataPair objects where the recipient has the address given as parameter to the request.
 // method Client - An Client endpoint struct point
-// param address - An account address
+// param address - An account address, see base.ParseAddress
 // param txHash - The 256 bit sha3 hash of the transaction up to which transactions are returned. (optional)
 // param txId - The transaction id up to which transactions are returned. (optional)
 // return - An slice of [TransactionMetaDataPair] struct
 // link http://bob.nem.ninja/docs/#transactionMetaDataPair
-func (c *Client) OutgoingTransactions(address, txHash, txId string) ([]TransactionMetaDataPair, error) {
-	params := map[string]string{"address": address}
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
+func (c *Client) OutgoingTransactions(address base.Address, txHash, txId string) ([]TransactionMetaDataPair, error) {
+	return c.OutgoingTransactionsCtx(context.Background(), address, txHash, txId)
+}
+
+// Same as OutgoingTransactions, the request is bound to ctx
+func (c *Client) OutgoingTransactionsCtx(ctx context.Context, address base.Address, txHash, txId string) ([]TransactionMetaDataPair, error) {
+	params := map[string]string{"address": address.String()}
 	if txHash != "" {
 		params["hash"] = txHash
 	}
@@ -499,22 +571,14 @@ func (c *Client) OutgoingTransactions(address, txHash, txId string) ([]Transacti
 		params["id"] = txId
 	}
 
-	c.URL.Path = "/account/transfers/outgoing"
-	req, err := c.buildReq(params, nil, http.MethodGet)
+	req, err := c.buildReq("/account/transfers/outgoing", params, nil, http.MethodGet)
 	if err != nil {
 		return []TransactionMetaDataPair{}, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return []TransactionMetaDataPair{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []TransactionMetaDataPair{}, err
-	}
 
 	var data = struct{ Data []TransactionMetaDataPair }{}
 	if err := json.Unmarshal(byteArray, &data); err != nil {
@@ -527,37 +591,69 @@ func (c *Client) OutgoingTransactions(address, txHash, txId string) ([]Transacti
 // Gets the array of transactions for which an account is the sender or receiver and which
 // have not yet been included in a block.
 // method Client - An Client endpoint struct point
-// param address - An account address
+// param address - An account address, see base.ParseAddress
 // return - An slice of [UnconfirmedTransactionMetaDataPair] struct
 // link http://bob.nem.ninja/docs/#unconfirmedTransactionMetaDataPair
-func (c *Client) UnconfirmedTransactions(address string) ([]base.Transaction, error) {
+func (c *Client) UnconfirmedTransactions(address base.Address) ([]base.Transaction, error) {
+	return c.UnconfirmedTransactionsCtx(context.Background(), address)
+}
 
-	b := new(bytes.Buffer)
-	params := map[string]string{"address": address}
-	timeout := 10 * time.Second
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/account/unconfirmedTransactions"
-	req, err := c.buildReq(params, nil, http.MethodGet)
+// Same as UnconfirmedTransactions, the request is bound to ctx
+func (c *Client) UnconfirmedTransactionsCtx(ctx context.Context, address base.Address) ([]base.Transaction, error) {
+	params := map[string]string{"address": address.String()}
+	req, err := c.buildReq("/account/unconfirmedTransactions", params, nil, http.MethodGet)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		b := &bytes.Buffer{}
-		_, _ = b.ReadFrom(resp.Body)
-		return nil, errors.New(b.String())
+	return MapTransactions(bytes.NewBuffer(byteArray))
+}
+
+// Gets the array of transactions for which an account is the sender or receiver and which
+// have not yet been included in a block, with the meta data holding the inner transaction
+// hash of multisig transactions.
+// method Client - An Client endpoint struct point
+// param address - An account address, see base.ParseAddress
+// return - An slice of [UnconfirmedTransactionMetaDataPair] struct
+// link http://bob.nem.ninja/docs/#unconfirmedTransactionMetaDataPair
+func (c *Client) UnconfirmedTransactionsMeta(address base.Address) ([]UnconfirmedTransactionMetaDataPair, error) {
+	return c.UnconfirmedTransactionsMetaCtx(context.Background(), address)
+}
+
+// Same as UnconfirmedTransactionsMeta, the request is bound to ctx
+func (c *Client) UnconfirmedTransactionsMetaCtx(ctx context.Context, address base.Address) ([]UnconfirmedTransactionMetaDataPair, error) {
+	params := map[string]string{"address": address.String()}
+	req, err := c.buildReq("/account/unconfirmedTransactions", params, nil, http.MethodGet)
+	if err != nil {
+		return nil, err
+	}
+	byteArray, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
-	_, _ = io.Copy(b, resp.Body)
+	var data = struct{ Data []json.RawMessage }{}
+	if err := json.Unmarshal(byteArray, &data); err != nil {
+		return nil, err
+	}
 
-	return MapTransactions(b)
+	pairs := make([]UnconfirmedTransactionMetaDataPair, len(data.Data))
+	for i, raw := range data.Data {
+		var meta = struct{ Meta MetaData }{}
+		if err := json.Unmarshal(raw, &meta); err != nil {
+			return nil, err
+		}
+		_, tx, err := MapTransaction(bytes.NewBuffer(raw))
+		if err != nil {
+			return nil, err
+		}
+		pairs[i] = UnconfirmedTransactionMetaDataPair{Meta: meta.Meta, Transaction: tx}
+	}
+	return pairs, nil
 }
 
 // Gets information about the maximum number of allowed harvesters and
@@ -566,21 +662,19 @@ func (c *Client) UnconfirmedTransactions(address string) ([]base.Transaction, er
 // return - An [UnlockInfo] struct
 // link http://bob.nem.ninja/docs/#retrieving-the-unlock-info
 func (c *Client) UnlockInfo() (UnlockInfo, error) {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/account/unlocked/info"
-	req, err := c.buildReq(nil, nil, http.MethodPost)
+	return c.UnlockInfoCtx(context.Background())
+}
+
+// Same as UnlockInfo, the request is bound to ctx
+func (c *Client) UnlockInfoCtx(ctx context.Context) (UnlockInfo, error) {
+	req, err := c.buildReq("/account/unlocked/info", nil, nil, http.MethodPost)
 	if err != nil {
 		return UnlockInfo{}, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return UnlockInfo{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
 
 	var data = UnlockInfo{}
 	if err := json.Unmarshal(byteArray, &data); err != nil {
@@ -595,34 +689,23 @@ func (c *Client) UnlockInfo() (UnlockInfo, error) {
 // param privateKey - A delegated account private key
 // return - error
 func (c *Client) StartHarvesting(privateKey string) error {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
+	return c.StartHarvestingCtx(context.Background(), privateKey)
+}
 
+// Same as StartHarvesting, the request is bound to ctx
+func (c *Client) StartHarvestingCtx(ctx context.Context, privateKey string) error {
 	payload, err := json.Marshal(map[string]string{"value": privateKey})
 	if err != nil {
 		return err
 	}
 
-	c.URL.Path = "/account/unlock"
-	req, err := c.buildReq(nil, payload, http.MethodPost)
+	req, err := c.buildReq("/account/unlock", nil, payload, http.MethodPost)
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return err
-	}
-	return nil
+	_, err = c.do(ctx, req)
+	return err
 }
 
 // Locks an account (stops harvesting).
@@ -630,63 +713,45 @@ func (c *Client) StartHarvesting(privateKey string) error {
 // param privateKey - A delegated account private key
 // return - error
 func (c *Client) StopHarvesting(privateKey string) error {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
+	return c.StopHarvestingCtx(context.Background(), privateKey)
+}
 
+// Same as StopHarvesting, the request is bound to ctx
+func (c *Client) StopHarvestingCtx(ctx context.Context, privateKey string) error {
 	payload, err := json.Marshal(map[string]string{"value": privateKey})
 	if err != nil {
 		return err
 	}
 
-	c.URL.Path = "/account/lock"
-	req, err := c.buildReq(nil, payload, http.MethodPost)
+	req, err := c.buildReq("/account/lock", nil, payload, http.MethodPost)
 	if err != nil {
 		return err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return err
-	}
-	return nil
+	_, err = c.do(ctx, req)
+	return err
 }
 
 // Gets the AccountMetaDataPair of the account for which the given account is the delegate account
 // method Client - An Client endpoint struct point
-// param address - An account address
+// param address - An account address, see base.ParseAddress
 // return - An struct[AccountMetaDataPair] struct
 // link http://bob.nem.ninja/docs/#accountMetaDataPair
-func (c *Client) Forwarded(address string) (AccountMetaDataPair, error) {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/account/get/forwarded"
-	req, err := c.buildReq(map[string]string{"address": address}, nil, http.MethodGet)
+func (c *Client) Forwarded(address base.Address) (AccountMetaDataPair, error) {
+	return c.ForwardedCtx(context.Background(), address)
+}
+
+// Same as Forwarded, the request is bound to ctx
+func (c *Client) ForwardedCtx(ctx context.Context, address base.Address) (AccountMetaDataPair, error) {
+	req, err := c.buildReq("/account/get/forwarded", map[string]string{"address": address.String()}, nil, http.MethodGet)
 	if err != nil {
 		return AccountMetaDataPair{}, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return AccountMetaDataPair{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return AccountMetaDataPair{}, err
-	}
 
 	var data AccountMetaDataPair
 	if err := json.Unmarshal(byteArray, &data); err != nil {
@@ -698,35 +763,28 @@ func (c *Client) Forwarded(address string) (AccountMetaDataPair, error) {
 
 // Gets namespaces that an account owns
 // method Client - An Client endpoint struct point
-// param address - An account address
+// param address - An account address, see base.ParseAddress
 // param parent - The namespace parent (optional)
 // return - An slice of [Namespace] struct
 // link http://bob.nem.ninja/docs/#namespaceMetaDataPair
-func (c *Client) NamespacesOwned(address, parent string) ([]Namespace, error) {
-	params := map[string]string{"address": address}
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
+func (c *Client) NamespacesOwned(address base.Address, parent string) ([]Namespace, error) {
+	return c.NamespacesOwnedCtx(context.Background(), address, parent)
+}
+
+// Same as NamespacesOwned, the request is bound to ctx
+func (c *Client) NamespacesOwnedCtx(ctx context.Context, address base.Address, parent string) ([]Namespace, error) {
+	params := map[string]string{"address": address.String()}
 	if parent != "" {
 		params["parent"] = parent
 	}
-	c.URL.Path = "/account/namespace/page"
-	req, err := c.buildReq(params, nil, http.MethodGet)
+	req, err := c.buildReq("/account/namespace/page", params, nil, http.MethodGet)
 	if err != nil {
 		return []Namespace{}, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return []Namespace{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []Namespace{}, err
-	}
 
 	var data = struct {
 		Data []Namespace
@@ -744,35 +802,28 @@ func (c *Client) NamespacesOwned(address, parent string) ([]Namespace, error) {
 
 // Gets mosaic definitions that an account has created
 // method Client - An Client endpoint struct point
-// param address - An account address
+// param address - An account address, see base.ParseAddress
 // param parent - The namespace parent (optional)
 // return - An slice of [MosaicDefinition] struct
 // link http://bob.nem.ninja/docs/#mosaicDefinition
-func (c *Client) MosaicDefinitionsCreated(address, parent string) ([]base.MosaicDefinition, error) {
-	params := map[string]string{"address": address}
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
+func (c *Client) MosaicDefinitionsCreated(address base.Address, parent string) ([]base.MosaicDefinition, error) {
+	return c.MosaicDefinitionsCreatedCtx(context.Background(), address, parent)
+}
+
+// Same as MosaicDefinitionsCreated, the request is bound to ctx
+func (c *Client) MosaicDefinitionsCreatedCtx(ctx context.Context, address base.Address, parent string) ([]base.MosaicDefinition, error) {
+	params := map[string]string{"address": address.String()}
 	if parent != "" {
 		params["parent"] = parent
 	}
-	c.URL.Path = "/account/mosaic/definition/page"
-	req, err := c.buildReq(params, nil, http.MethodGet)
+	req, err := c.buildReq("/account/mosaic/definition/page", params, nil, http.MethodGet)
 	if err != nil {
 		return []base.MosaicDefinition{}, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return []base.MosaicDefinition{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []base.MosaicDefinition{}, err
-	}
 
 	var data = struct {
 		Data []base.MosaicDefinition
@@ -790,31 +841,24 @@ func (c *Client) MosaicDefinitionsCreated(address, parent string) ([]base.Mosaic
 
 // Gets mosaic definitions that an account owns
 // method Client - An Client endpoint struct point
-// param address - An account address
+// param address - An account address, see base.ParseAddress
 // return - An slice of [MosaicDefinition] struct
 // link http://bob.nem.ninja/docs/#mosaicDefinition
-func (c *Client) MosaicDefinitionsOwned(address string) ([]base.MosaicDefinition, error) {
-	params := map[string]string{"address": address}
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/account/mosaic/owned/definition"
-	req, err := c.buildReq(params, nil, http.MethodGet)
+func (c *Client) MosaicDefinitionsOwned(address base.Address) ([]base.MosaicDefinition, error) {
+	return c.MosaicDefinitionsOwnedCtx(context.Background(), address)
+}
+
+// Same as MosaicDefinitionsOwned, the request is bound to ctx
+func (c *Client) MosaicDefinitionsOwnedCtx(ctx context.Context, address base.Address) ([]base.MosaicDefinition, error) {
+	params := map[string]string{"address": address.String()}
+	req, err := c.buildReq("/account/mosaic/owned/definition", params, nil, http.MethodGet)
 	if err != nil {
 		return []base.MosaicDefinition{}, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return []base.MosaicDefinition{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []base.MosaicDefinition{}, err
-	}
 
 	var data = struct {
 		Data []base.MosaicDefinition
@@ -832,31 +876,24 @@ func (c *Client) MosaicDefinitionsOwned(address string) ([]base.MosaicDefinition
 
 // Gets mosaics that an account owns
 // method Client - An Client endpoint struct point
-// param address - An account address
+// param address - An account address, see base.ParseAddress
 // return - An slice of [Mosaic] struct
 // link http://bob.nem.ninja/docs/#mosaic
-func (c *Client) MosaicsOwned(address string) ([]base.Mosaic, error) {
-	params := map[string]string{"address": address}
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/account/mosaic/owned"
-	req, err := c.buildReq(params, nil, http.MethodGet)
+func (c *Client) MosaicsOwned(address base.Address) ([]base.Mosaic, error) {
+	return c.MosaicsOwnedCtx(context.Background(), address)
+}
+
+// Same as MosaicsOwned, the request is bound to ctx
+func (c *Client) MosaicsOwnedCtx(ctx context.Context, address base.Address) ([]base.Mosaic, error) {
+	params := map[string]string{"address": address.String()}
+	req, err := c.buildReq("/account/mosaic/owned", params, nil, http.MethodGet)
 	if err != nil {
 		return []base.Mosaic{}, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return []base.Mosaic{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []base.Mosaic{}, err
-	}
 
 	var data = struct {
 		Data []base.Mosaic
@@ -874,17 +911,18 @@ func (c *Client) MosaicsOwned(address string) ([]base.Mosaic, error) {
 
 // Gets all transactions of an account
 // method Client - An Client endpoint struct point
-// param address - An account address
+// param address - An account address, see base.ParseAddress
 // param txHash - The 256 bit sha3 hash of the transaction up to which transactions are returned. (optional)
 // param txId - The transaction id up to which transactions are returned. (optional)
 // return - An slice of [TransactionMetaDataPair] struct
 // link http://bob.nem.ninja/docs/#transactionMetaDataPair
-func (c *Client) AllTransactions(address, txHash, txId string) ([]TransactionMetaDataPair, error) {
-	params := map[string]string{"address": address}
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
+func (c *Client) AllTransactions(address base.Address, txHash, txId string) ([]TransactionMetaDataPair, error) {
+	return c.AllTransactionsCtx(context.Background(), address, txHash, txId)
+}
+
+// Same as AllTransactions, the request is bound to ctx
+func (c *Client) AllTransactionsCtx(ctx context.Context, address base.Address, txHash, txId string) ([]TransactionMetaDataPair, error) {
+	params := map[string]string{"address": address.String()}
 	if txHash != "" {
 		params["hash"] = txHash
 	}
@@ -892,22 +930,14 @@ func (c *Client) AllTransactions(address, txHash, txId string) ([]TransactionMet
 		params["id"] = txId
 	}
 
-	c.URL.Path = "/account/transfers/all"
-	req, err := c.buildReq(params, nil, http.MethodGet)
+	req, err := c.buildReq("/account/transfers/all", params, nil, http.MethodGet)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []TransactionMetaDataPair{}, err
-	}
 
 	var data = struct{ Data []TransactionMetaDataPair }{}
 	if err := json.Unmarshal(byteArray, &data); err != nil {
@@ -922,37 +952,30 @@ func (c *Client) AllTransactions(address, txHash, txId string) ([]TransactionMet
 // param addresses - An array of account addresses
 // return - An slice that contains an array of [AccountMetaDataPair] struct
 // link http://bob.nem.ninja/docs/#accountMetaDataPair
-func (c Client) GetBatchAccountData(addresses []string) ([]AccountMetaDataPair, error) {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
+func (c Client) GetBatchAccountData(addresses []base.Address) ([]AccountMetaDataPair, error) {
+	return c.GetBatchAccountDataCtx(context.Background(), addresses)
+}
+
+// Same as GetBatchAccountData, the request is bound to ctx
+func (c Client) GetBatchAccountDataCtx(ctx context.Context, addresses []base.Address) ([]AccountMetaDataPair, error) {
 	var payloadBuilder []map[string]string
 	for _, address := range addresses {
-		payloadBuilder = append(payloadBuilder, map[string]string{"account": address})
+		payloadBuilder = append(payloadBuilder, map[string]string{"account": address.String()})
 	}
 	payload, err := json.Marshal(map[string][]map[string]string{"data": payloadBuilder})
 	if err != nil {
 		return []AccountMetaDataPair{}, err
 	}
-	c.URL.Path = "/account/get/batch"
-	req, err := c.buildReq(nil, payload, http.MethodPost)
+	req, err := c.buildReq("/account/get/batch", nil, payload, http.MethodPost)
 	if err != nil {
 		return []AccountMetaDataPair{}, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return []AccountMetaDataPair{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []AccountMetaDataPair{}, err
-	}
 	// The data is returned as a nested json array
 	// This enables us to not return the array nested
 	// as a value under a "data" key
@@ -968,15 +991,16 @@ func (c Client) GetBatchAccountData(addresses []string) ([]AccountMetaDataPair,
 // param addresses - An array of account addresses
 // param block - The block height
 // return - An slice Account information for all the accounts on the given block
-func (c Client) GetBatchHistoricalAccountData(addresses []string, block int) ([]AccountMetaDataPair, error) {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
+func (c Client) GetBatchHistoricalAccountData(addresses []base.Address, block int) ([]AccountMetaDataPair, error) {
+	return c.GetBatchHistoricalAccountDataCtx(context.Background(), addresses, block)
+}
+
+// Same as GetBatchHistoricalAccountData, the request is bound to ctx
+func (c Client) GetBatchHistoricalAccountDataCtx(ctx context.Context, addresses []base.Address, block int) ([]AccountMetaDataPair, error) {
 	var Accounts []Account
 
 	for _, address := range addresses {
-		Accounts = append(Accounts, Account{Account: address})
+		Accounts = append(Accounts, Account{Account: address.String()})
 	}
 	Ojt := HbAccountData{}
 	Ojt.Accounts = &Accounts
@@ -991,24 +1015,16 @@ func (c Client) GetBatchHistoricalAccountData(addresses []string, block int) ([]
 		return []AccountMetaDataPair{}, err
 	}
 
-	c.URL.Path = "/account/historical/get/batch"
-	req, err := c.buildReq(nil, payload, http.MethodPost)
+	req, err := c.buildReq("/account/historical/get/batch", nil, payload, http.MethodPost)
 	if err != nil {
 		return []AccountMetaDataPair{}, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return []AccountMetaDataPair{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []AccountMetaDataPair{}, err
-	}
 	// The data is returned as a nested json array
 	// This enables us to not return the array nested
 	// as a value under a "data" key
@@ -1021,39 +1037,32 @@ func (c Client) GetBatchHistoricalAccountData(addresses []string, block int) ([]
 
 // Gets the AccountMetaDataPair of an account from a certain block.
 // method Client - An Client endpoint struct point
-// param address - An account address
+// param address - An account address, see base.ParseAddress
 // param block - the block height
 // return - An slice [AccountMetaDataPair] struct
 // link http://bob.nem.ninja/docs/#accountMetaDataPair
-func (c Client) GetHistoricalAccountData(addresses string, block int) ([]AccountMetaDataPair, error) {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	params := map[string]string{"address": addresses}
+func (c Client) GetHistoricalAccountData(address base.Address, block int) ([]AccountMetaDataPair, error) {
+	return c.GetHistoricalAccountDataCtx(context.Background(), address, block)
+}
+
+// Same as GetHistoricalAccountData, the request is bound to ctx
+func (c Client) GetHistoricalAccountDataCtx(ctx context.Context, address base.Address, block int) ([]AccountMetaDataPair, error) {
+	params := map[string]string{"address": address.String()}
 
 	bck := fmt.Sprintf("%v", block)
 	params["startHeight"] = bck
 	params["endHeight"] = bck
 	params["incrementBy"] = "1"
 
-	c.URL.Path = "/account/historical/get"
-	req, err := c.buildReq(params, nil, http.MethodGet)
+	req, err := c.buildReq("/account/historical/get", params, nil, http.MethodGet)
 	if err != nil {
 		return []AccountMetaDataPair{}, err
 	}
 
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return []AccountMetaDataPair{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []AccountMetaDataPair{}, err
-	}
 	// The data is returned as a nested json array
 	// This enables us to not return the array nested
 	// as a value under a "data" key
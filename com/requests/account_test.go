@@ -0,0 +1,196 @@
+package requests
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/base"
+)
+
+const (
+	fixtureSigner = "5f06cd912b6e0a6e7a0a290aa83c82c417c0e6700b751385efca1f060b7a9a9f"
+	fixtureKey    = "a1aaca6c17a24252e674d155713cdf55996ad00175be4af02a20c67b59f9fe8a"
+	fixtureHash   = "e1f7b2e1dd6e1f5ad8a4c34e1bc1ba4b41def2b8d44b6b0e5bd7d82a3b6a6a54"
+	fixtureTo     = "TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S"
+)
+
+// Check the common fields of a fixture transaction
+func checkCommon(t *testing.T, c base.CommonTransaction, txType, version int, signer string, fee base.Amount) {
+	t.Helper()
+	if c.Type != txType || c.Version != version || c.Signer != signer || c.Fee != fee {
+		t.Errorf("common = type %d version %d signer %s fee %d, want %d %d %s %d",
+			c.Type, c.Version, c.Signer, c.Fee, txType, version, signer, fee)
+	}
+	if c.TimeStamp == nil || *c.TimeStamp != 86200000 || c.Deadline == nil || *c.Deadline != 86203600 {
+		t.Errorf("timeStamp %v deadline %v, want 86200000 and 86203600", c.TimeStamp, c.Deadline)
+	}
+}
+
+// The NIS JSON of every transaction type, as returned by the account endpoints
+func TestMapTransaction(t *testing.T) {
+	const testnetV1, testnetV2 = -1744830463, -1744830462
+	tests := []struct {
+		file   string
+		id     int
+		height int64
+		check  func(t *testing.T, tx base.Transaction)
+	}{
+		{"transfer.json", 1201, 1500, func(t *testing.T, tx base.Transaction) {
+			tr, ok := tx.(*base.TransactionMosaic)
+			if !ok {
+				t.Fatalf("%T, want *base.TransactionMosaic", tx)
+			}
+			if tr.Type != 257 || tr.Version != testnetV2 || tr.Signer != fixtureSigner || tr.Fee != 100000 || tr.TimeStamp != 86200000 {
+				t.Errorf("common = %+v", tr.AbstractTransaction)
+			}
+			if tr.Amount != base.XEM || tr.Recipient != fixtureTo || tr.Message == nil || tr.Message.Payload != "48656c6c6f" {
+				t.Errorf("transfer = %+v", tr.AbstractTransaction)
+			}
+			if len(tr.Mosaics) != 2 || tr.Mosaics[0].MosaicID != (base.MosaicID{NamespaceID: "foo", Name: "bar"}) || tr.Mosaics[0].Quantity != 5000 {
+				t.Errorf("mosaics = %+v", tr.Mosaics)
+			}
+		}},
+		{"importance_transfer.json", 1202, 1501, func(t *testing.T, tx base.Transaction) {
+			it, ok := tx.(*base.ImportanceTransferTransaction)
+			if !ok {
+				t.Fatalf("%T, want *base.ImportanceTransferTransaction", tx)
+			}
+			checkCommon(t, it.CommonTransaction, 2049, testnetV1, fixtureSigner, 150000)
+			if it.Mode != 1 || it.RemoteAccount != fixtureKey {
+				t.Errorf("mode %d remote %s", it.Mode, it.RemoteAccount)
+			}
+		}},
+		{"multisig_modification.json", 1203, 1502, func(t *testing.T, tx base.Transaction) {
+			m, ok := tx.(*base.MultisigAggregateModificationTransaction)
+			if !ok {
+				t.Fatalf("%T, want *base.MultisigAggregateModificationTransaction", tx)
+			}
+			checkCommon(t, m.CommonTransaction, 4097, testnetV2, fixtureSigner, 500000)
+			if len(m.Modifications) != 1 || m.Modifications[0] != (base.ConsModif{ModificationType: 1, CosignatoryAccount: fixtureKey}) {
+				t.Errorf("modifications = %+v", m.Modifications)
+			}
+			if m.MinCosignatories == nil || m.MinCosignatories.RelativeChange != 1 {
+				t.Errorf("minCosignatories = %+v", m.MinCosignatories)
+			}
+		}},
+		{"multisig_signature.json", 1204, 1503, func(t *testing.T, tx base.Transaction) {
+			s, ok := tx.(*base.MultisigSignatureTransaction)
+			if !ok {
+				t.Fatalf("%T, want *base.MultisigSignatureTransaction", tx)
+			}
+			checkCommon(t, s.CommonTransaction, 4098, testnetV1, fixtureKey, 150000)
+			if s.OtherHash.Data != fixtureHash || s.OtherAccount != fixtureTo {
+				t.Errorf("otherHash %s otherAccount %s", s.OtherHash.Data, s.OtherAccount)
+			}
+		}},
+		{"multisig.json", 1205, 1504, func(t *testing.T, tx base.Transaction) {
+			m, ok := tx.(*base.MultiSignTransaction)
+			if !ok {
+				t.Fatalf("%T, want *base.MultiSignTransaction", tx)
+			}
+			checkCommon(t, m.CommonTransaction, 4100, testnetV1, fixtureKey, 150000)
+			inner, ok := m.OtherTrans.(*base.MultisigAggregateModificationTransaction)
+			if !ok {
+				t.Fatalf("otherTrans %T, want *base.MultisigAggregateModificationTransaction", m.OtherTrans)
+			}
+			checkCommon(t, inner.CommonTransaction, 4097, testnetV2, fixtureSigner, 500000)
+			if len(inner.Modifications) != 1 || inner.Modifications[0].ModificationType != 2 ||
+				inner.MinCosignatories == nil || inner.MinCosignatories.RelativeChange != -1 {
+				t.Errorf("otherTrans = %+v", inner)
+			}
+			if len(m.Signatures) != 1 || m.Signatures[0].Type != 4098 || m.Signatures[0].OtherHash.Data != fixtureHash ||
+				m.Signatures[0].Signer != fixtureKey || m.Signatures[0].TimeStamp != 86200100 {
+				t.Errorf("signatures = %+v", m.Signatures)
+			}
+		}},
+		{"multisig_transfer.json", 1209, 1508, func(t *testing.T, tx base.Transaction) {
+			m, ok := tx.(*base.MultiSignTransaction)
+			if !ok {
+				t.Fatalf("%T, want *base.MultiSignTransaction", tx)
+			}
+			inner, ok := m.OtherTrans.(*base.TransferTransaction)
+			if !ok {
+				t.Fatalf("otherTrans %T, want *base.TransferTransaction", m.OtherTrans)
+			}
+			// Above 2^53, a float64 would round them
+			if inner.Amount != 9007199254740993 || len(inner.Mosaics) != 1 || inner.Mosaics[0].Quantity != 9007199254740995 {
+				t.Errorf("otherTrans = %+v", inner)
+			}
+		}},
+		{"provision_namespace.json", 1206, 1505, func(t *testing.T, tx base.Transaction) {
+			p, ok := tx.(*base.ProvisionNamespaceTransaction)
+			if !ok {
+				t.Fatalf("%T, want *base.ProvisionNamespaceTransaction", tx)
+			}
+			checkCommon(t, p.CommonTransaction, 8193, testnetV1, fixtureSigner, 150000)
+			if p.NewPart != "foo" || p.Parent != "" || p.RentalFee != 100*base.XEM ||
+				p.RentalFeeSink != "TAMESPACEWH4MKFMBCVFERDPOOP4FK7MTDJEYP35" {
+				t.Errorf("namespace = %+v", p)
+			}
+		}},
+		{"mosaic_definition.json", 1207, 1506, func(t *testing.T, tx base.Transaction) {
+			d, ok := tx.(*base.MosaicDefinitionCreationTransaction)
+			if !ok {
+				t.Fatalf("%T, want *base.MosaicDefinitionCreationTransaction", tx)
+			}
+			checkCommon(t, d.CommonTransaction, 16385, testnetV1, fixtureSigner, 150000)
+			if d.CreationFee != 10*base.XEM || d.CreationFeeSink != "TBMOSAICOD4F54EE5CDMR23CCBGOAM2XSJBR5OLC" {
+				t.Errorf("creation fee %d to %s", d.CreationFee, d.CreationFeeSink)
+			}
+			md := d.MosaicDefinition
+			if md.Creator != fixtureSigner || md.ID != (base.MosaicID{NamespaceID: "foo", Name: "bar"}) ||
+				md.Description != "a mosaic" || len(md.Properties) != 4 || md.Properties[0] != (base.Properties{Name: "divisibility", Value: "3"}) {
+				t.Errorf("mosaicDefinition = %+v", md)
+			}
+			if md.Levy != (base.Levy{}) {
+				t.Errorf("levy = %+v, want none", md.Levy)
+			}
+		}},
+		{"mosaic_supply_change.json", 1208, 1507, func(t *testing.T, tx base.Transaction) {
+			s, ok := tx.(*base.MosaicSupplyChangeTransaction)
+			if !ok {
+				t.Fatalf("%T, want *base.MosaicSupplyChangeTransaction", tx)
+			}
+			checkCommon(t, s.CommonTransaction, 16386, testnetV1, fixtureSigner, 150000)
+			if s.MosaicID != (base.MosaicID{NamespaceID: "foo", Name: "bar"}) || s.SupplyType != 1 || s.Delta != 1000 {
+				t.Errorf("supply change = %+v", s)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			meta, tx, err := MapTransaction(bytes.NewBuffer(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if meta.ID != tt.id || meta.Height != tt.height || meta.Hash.Data != fixtureHash {
+				t.Errorf("meta = %+v, want id %d at %d", meta, tt.id, tt.height)
+			}
+			tt.check(t, tx)
+
+			// The same through TransactionMetaDataPair
+			var pair TransactionMetaDataPair
+			if err := pair.UnmarshalJSON(data); err != nil || pair.Meta.ID != tt.id || pair.Transaction == nil {
+				t.Errorf("TransactionMetaDataPair = %+v, %v", pair, err)
+			}
+		})
+	}
+}
+
+func TestMapTransactionUnknownType(t *testing.T) {
+	for _, data := range []string{
+		`{"meta": {"id": 1}, "transaction": {"type": 9999}}`,
+		`{"type": 9999}`,
+		`{"meta": {"id": 1}, "transaction": {"type": 4100, "otherTrans": {"type": 9999}}}`,
+	} {
+		if _, _, err := MapTransaction(bytes.NewBufferString(data)); err == nil {
+			t.Errorf("%s: no error", data)
+		}
+	}
+}
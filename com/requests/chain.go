@@ -1,14 +1,12 @@
 package requests
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"github.com/isarq/nem-sdk-go/utils"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
-	"time"
 
 	. "github.com/isarq/nem-sdk-go/base"
 )
@@ -23,10 +21,18 @@ type TimeStamps struct {
 	ReceiveTimeStamp int64 `json:"receiveTimeStamp"`
 }
 
+// A Client is safe for concurrent use, each request builds its own URL from the base URL.
+// The fields must not be modified once the client is in use.
 type Client struct {
-	Node    Node
-	URL     url.URL
+	Node Node
+	// URL is the base URL of the node
+	URL url.URL
+	// Request replaces the HTTP round trip when set, it returns the body of a successful response
 	Request func(*http.Request) ([]byte, error)
+	// HTTPClient sends the requests, a client with DefaultTimeout is used when nil
+	HTTPClient *http.Client
+	// UserAgent is sent as the User-Agent header when not empty
+	UserAgent string
 }
 
 type Block struct {
@@ -57,11 +63,19 @@ type ExplorerTransferViewModel struct {
 	InnerHash string              `json:"innerHash"`
 }
 
-func NewClient(node Node) *Client {
+// Create a NIS client for a node
+// param node - A NIS endpoint struct
+// param options - Options configuring the HTTP transport (see WithHTTPClient)
+// return - A client point
+func NewClient(node Node, options ...ClientOption) *Client {
 	protocol := strings.Split(node.Host, "://")
 	host := utils.FormatEndpoint(node)
 
-	return &Client{Node: node, URL: url.URL{Scheme: protocol[0], Host: host}}
+	c := &Client{Node: node, URL: url.URL{Scheme: protocol[0], Host: host}}
+	for _, option := range options {
+		option(c)
+	}
+	return c
 }
 
 // Gets the current height of the block chain.
@@ -69,25 +83,18 @@ func NewClient(node Node) *Client {
 // return {struct} - A [BlockHeight] struct
 // link http://bob.nem.ninja/docs/#block-chain-height
 func (c *Client) Height() (BlockHeight, error) {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/chain/height"
-	req, err := c.buildReq(nil, nil, http.MethodGet)
-	if err != nil {
-		return BlockHeight{}, err
-	}
+	return c.HeightCtx(context.Background())
+}
 
-	resp, err := client.Do(req)
+// Same as Height, the request is bound to ctx
+func (c *Client) HeightCtx(ctx context.Context) (BlockHeight, error) {
+	req, err := c.buildReq("/chain/height", nil, nil, http.MethodGet)
 	if err != nil {
 		return BlockHeight{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
 
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
+	byteArray, err := c.do(ctx, req)
+	if err != nil {
 		return BlockHeight{}, err
 	}
 
@@ -102,25 +109,18 @@ func (c *Client) Height() (BlockHeight, error) {
 // method Client - An Client endpoint struct point
 // return
 func (c *Client) LastBlock() (BlockHeight, error) {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/chain/last-block"
-	req, err := c.buildReq(nil, nil, http.MethodGet)
-	if err != nil {
-		return BlockHeight{}, err
-	}
+	return c.LastBlockCtx(context.Background())
+}
 
-	resp, err := client.Do(req)
+// Same as LastBlock, the request is bound to ctx
+func (c *Client) LastBlockCtx(ctx context.Context) (BlockHeight, error) {
+	req, err := c.buildReq("/chain/last-block", nil, nil, http.MethodGet)
 	if err != nil {
 		return BlockHeight{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
 
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
+	byteArray, err := c.do(ctx, req)
+	if err != nil {
 		return BlockHeight{}, err
 	}
 
@@ -136,25 +136,18 @@ func (c *Client) LastBlock() (BlockHeight, error) {
 // return - A [communicationTimeStamps]
 // link http://bob.nem.ninja/docs/#communicationTimeStamps
 func (c *Client) Time() (TimeStamps, error) {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/time-sync/network-time"
-	req, err := c.buildReq(nil, nil, http.MethodGet)
-	if err != nil {
-		return TimeStamps{}, err
-	}
+	return c.TimeCtx(context.Background())
+}
 
-	resp, err := client.Do(req)
+// Same as Time, the request is bound to ctx
+func (c *Client) TimeCtx(ctx context.Context) (TimeStamps, error) {
+	req, err := c.buildReq("/time-sync/network-time", nil, nil, http.MethodGet)
 	if err != nil {
 		return TimeStamps{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
 
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
+	byteArray, err := c.do(ctx, req)
+	if err != nil {
 		return TimeStamps{}, err
 	}
 
@@ -170,32 +163,25 @@ func (c *Client) Time() (TimeStamps, error) {
 // param height - The height of the block
 // return - A block struct
 func (c *Client) BlockByHeight(height int64) (Block, error) {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
+	return c.BlockByHeightCtx(context.Background(), height)
+}
 
+// Same as BlockByHeight, the request is bound to ctx
+func (c *Client) BlockByHeightCtx(ctx context.Context, height int64) (Block, error) {
 	payload, err := json.Marshal(map[string]int64{"height": height})
 	if err != nil {
 		return Block{}, err
 	}
 
-	c.URL.Path = "/block/at/public"
-	req, err := c.buildReq(nil, payload, http.MethodPost)
+	req, err := c.buildReq("/block/at/public", nil, payload, http.MethodPost)
 	if err != nil {
 		return Block{}, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return Block{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return Block{}, err
-	}
 
 	var data Block
 	if err := json.Unmarshal(byteArray, &data); err != nil {
@@ -210,32 +196,25 @@ func (c *Client) BlockByHeight(height int64) (Block, error) {
 // return - A array of ExplorerBlockViewModel struct
 // link https://nemproject.github.io/#getting-part-of-a-chain
 func (c *Client) BlockAfterByHeight(height int64) ([]ExplorerBlockViewModel, error) {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
+	return c.BlockAfterByHeightCtx(context.Background(), height)
+}
 
+// Same as BlockAfterByHeight, the request is bound to ctx
+func (c *Client) BlockAfterByHeightCtx(ctx context.Context, height int64) ([]ExplorerBlockViewModel, error) {
 	payload, err := json.Marshal(map[string]int64{"height": height})
 	if err != nil {
 		return []ExplorerBlockViewModel{}, err
 	}
 
-	c.URL.Path = "/local/chain/blocks-after"
-	req, err := c.buildReq(nil, payload, http.MethodPost)
+	req, err := c.buildReq("/local/chain/blocks-after", nil, payload, http.MethodPost)
 	if err != nil {
 		return []ExplorerBlockViewModel{}, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return []ExplorerBlockViewModel{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []ExplorerBlockViewModel{}, err
-	}
 
 	var data struct {
 		Datas []ExplorerBlockViewModel `json:"data"`
@@ -0,0 +1,107 @@
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/base"
+)
+
+// A NIS stand-in answering with data derived from the requested path and query,
+// so a request sent to the wrong endpoint is detected by the caller
+func newTestNode(t *testing.T) *Client {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/chain/height", func(w http.ResponseWriter, r *http.Request) {
+		if len(r.URL.Query()) != 0 {
+			http.Error(w, "unexpected query "+r.URL.RawQuery, http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(BlockHeight{Height: 1234})
+	})
+	mux.HandleFunc("/account/get", func(w http.ResponseWriter, r *http.Request) {
+		address := r.URL.Query().Get("address")
+		if address == "" {
+			http.Error(w, "missing address", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(AccountMetaDataPair{Account: AccountInfo{Address: address}})
+	})
+	mux.HandleFunc("/namespace", func(w http.ResponseWriter, r *http.Request) {
+		namespace := r.URL.Query().Get("namespace")
+		if namespace == "" {
+			http.Error(w, "missing namespace", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(Namespace{Fqn: namespace})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewClient(base.Node{Host: "http://" + u.Hostname(), Port: port})
+}
+
+func TestClientConcurrentRequests(t *testing.T) {
+	c := newTestNode(t)
+
+	const workers = 32
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*3)
+	for i := 0; i < workers; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			height, err := c.Height()
+			if err == nil && height.Height != 1234 {
+				err = fmt.Errorf("Height() = %d, want 1234", height.Height)
+			}
+			if err != nil {
+				errs <- err
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			address := fmt.Sprintf("TADDRESS%032d", i)
+			account, err := c.AccountData(base.Address(address))
+			if err == nil && account.Account.Address != address {
+				err = fmt.Errorf("AccountData(%s) returned %s", address, account.Account.Address)
+			}
+			if err != nil {
+				errs <- err
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("ns%d", i)
+			namespace, err := c.Namespaceinfo(id)
+			if err == nil && namespace.Fqn != id {
+				err = fmt.Errorf("Namespaceinfo(%s) returned %s", id, namespace.Fqn)
+			}
+			if err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	if c.URL.Path != "" || c.URL.RawQuery != "" {
+		t.Errorf("base URL modified: %s", c.URL.String())
+	}
+}
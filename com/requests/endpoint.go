@@ -1,11 +1,9 @@
 package requests
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"io/ioutil"
 	"net/http"
-	"time"
 )
 
 type NemRequestResult struct {
@@ -38,25 +36,18 @@ type NemNodeInfo struct {
 // return - A [NemRequestResult] struct
 // link http://bob.nem.ninja/docs/#nemRequestResult
 func (c *Client) Heartbeat() (NemRequestResult, error) {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/heartbeat"
-	req, err := c.buildReq(nil, nil, http.MethodGet)
-	if err != nil {
-		return NemRequestResult{}, err
-	}
+	return c.HeartbeatCtx(context.Background())
+}
 
-	resp, err := client.Do(req)
+// Same as Heartbeat, the request is bound to ctx
+func (c *Client) HeartbeatCtx(ctx context.Context) (NemRequestResult, error) {
+	req, err := c.buildReq("/heartbeat", nil, nil, http.MethodGet)
 	if err != nil {
 		return NemRequestResult{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
 
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
+	byteArray, err := c.do(ctx, req)
+	if err != nil {
 		return NemRequestResult{}, err
 	}
 
@@ -68,25 +59,18 @@ func (c *Client) Heartbeat() (NemRequestResult, error) {
 }
 
 func (c *Client) GetNodeInfo() (NemNodeInfo, error) {
-	timeout := 10 * time.Second
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/node/info"
-	req, err := c.buildReq(nil, nil, http.MethodGet)
-	if err != nil {
-		return NemNodeInfo{}, err
-	}
+	return c.GetNodeInfoCtx(context.Background())
+}
 
-	resp, err := client.Do(req)
+// Same as GetNodeInfo, the request is bound to ctx
+func (c *Client) GetNodeInfoCtx(ctx context.Context) (NemNodeInfo, error) {
+	req, err := c.buildReq("/node/info", nil, nil, http.MethodGet)
 	if err != nil {
 		return NemNodeInfo{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
 
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
+	byteArray, err := c.do(ctx, req)
+	if err != nil {
 		return NemNodeInfo{}, err
 	}
 
@@ -0,0 +1,132 @@
+package requests
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/isarq/nem-sdk-go/base"
+)
+
+// The well-known failures of NIS, errors.Is matches them on a *NisError or an *AnnounceError
+var (
+	ErrFutureDeadline       = errors.New("nis: deadline too far in the future")
+	ErrPastDeadline         = errors.New("nis: deadline expired")
+	ErrInsufficientBalance  = errors.New("nis: insufficient balance")
+	ErrInsufficientFee      = errors.New("nis: insufficient fee")
+	ErrTimestampOutOfRange  = errors.New("nis: timestamp too far from the network time")
+	ErrDuplicateTransaction = errors.New("nis: transaction already known")
+	ErrInvalidSignature     = errors.New("nis: signature not verifiable")
+	ErrWrongNetwork         = errors.New("nis: wrong network")
+	ErrNotCosigner          = errors.New("nis: signer is not a cosignatory of the multisig account")
+	ErrMultisigMismatch     = errors.New("nis: no matching multisig transaction")
+	ErrMultisigAccount      = errors.New("nis: a multisig account can not initiate a transaction")
+	ErrNamespaceExists      = errors.New("nis: namespace already exists")
+	ErrNamespaceExpired     = errors.New("nis: namespace expired")
+	ErrNamespaceOwner       = errors.New("nis: namespace owned by another account")
+	ErrMosaicUnknown        = errors.New("nis: unknown mosaic")
+	ErrMosaicSupply         = errors.New("nis: mosaic supply out of range")
+	ErrMessageTooLarge      = errors.New("nis: message too large")
+	ErrOutOfSync            = errors.New("nis: node out of sync")
+)
+
+// The failures by NIS validation result name
+var failures = map[string]error{
+	"FAILURE_FUTURE_DEADLINE":                      ErrFutureDeadline,
+	"FAILURE_PAST_DEADLINE":                        ErrPastDeadline,
+	"FAILURE_INSUFFICIENT_BALANCE":                 ErrInsufficientBalance,
+	"FAILURE_INSUFFICIENT_FEE":                     ErrInsufficientFee,
+	"FAILURE_TIMESTAMP_TOO_FAR_IN_FUTURE":          ErrTimestampOutOfRange,
+	"FAILURE_TIMESTAMP_TOO_FAR_IN_PAST":            ErrTimestampOutOfRange,
+	"FAILURE_TRANSACTION_CACHED":                   ErrDuplicateTransaction,
+	"FAILURE_HASH_EXISTS":                          ErrDuplicateTransaction,
+	"FAILURE_SIGNATURE_NOT_VERIFIABLE":             ErrInvalidSignature,
+	"FAILURE_WRONG_NETWORK":                        ErrWrongNetwork,
+	"FAILURE_MULTISIG_NOT_A_COSIGNER":              ErrNotCosigner,
+	"FAILURE_MULTISIG_NO_MATCHING_MULTISIG":        ErrMultisigMismatch,
+	"FAILURE_MULTISIG_MISMATCHED_SIGNATURE":        ErrMultisigMismatch,
+	"FAILURE_TRANSACTION_NOT_ALLOWED_FOR_MULTISIG": ErrMultisigAccount,
+	"FAILURE_NAMESPACE_ALREADY_EXISTS":             ErrNamespaceExists,
+	"FAILURE_NAMESPACE_EXPIRED":                    ErrNamespaceExpired,
+	"FAILURE_NAMESPACE_OWNER_CONFLICT":             ErrNamespaceOwner,
+	"FAILURE_MOSAIC_UNKNOWN":                       ErrMosaicUnknown,
+	"FAILURE_MOSAIC_MAX_SUPPLY_EXCEEDED":           ErrMosaicSupply,
+	"FAILURE_MOSAIC_SUPPLY_NEGATIVE":               ErrMosaicSupply,
+	"FAILURE_MESSAGE_TOO_LARGE":                    ErrMessageTooLarge,
+	"FAILURE_ENTITY_UNUSABLE_OUT_OF_SYNC":          ErrOutOfSync,
+}
+
+// The failures by NIS validation result code, used when the message is not a known name
+var failureCodes = map[int]error{
+	3: ErrFutureDeadline,
+	4: ErrPastDeadline,
+	5: ErrInsufficientBalance,
+	6: ErrTimestampOutOfRange,
+	7: ErrTimestampOutOfRange,
+}
+
+// Find the sentinel error of a NIS validation result
+func failure(code int, message string) error {
+	if err, ok := failures[strings.TrimSpace(message)]; ok {
+		return err
+	}
+	return failureCodes[code]
+}
+
+// A NisError is a response of NIS other than 200
+// The sentinel errors (e.g. ErrInsufficientBalance) are matched with errors.Is.
+type NisError struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// Details is the error object of NIS, empty when the body is not one
+	Details base.Error
+	// Body is the body of the response
+	Body []byte
+}
+
+// Decode the body of a response other than 200
+func newNisError(statusCode int, body []byte) *NisError {
+	e := &NisError{StatusCode: statusCode, Body: body}
+	json.Unmarshal(body, &e.Details)
+	return e
+}
+
+func (e *NisError) Error() string {
+	if e.Details.Message == "" {
+		if len(e.Body) == 0 {
+			return "nis: " + strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode)
+		}
+		return string(e.Body)
+	}
+	if e.Details.Error == "" {
+		return "nis: " + e.Details.Message
+	}
+	return "nis: " + e.Details.Error + ": " + e.Details.Message
+}
+
+// Unwrap returns the sentinel error of the failure, nil when it is not a well-known one
+func (e *NisError) Unwrap() error {
+	return failure(0, e.Details.Message)
+}
+
+// Temporary reports if the request may succeed on another node or later
+func (e *NisError) Temporary() bool {
+	return e.StatusCode >= 500 || errors.Is(e, ErrOutOfSync)
+}
+
+// An AnnounceError is an announce rejected by NIS
+// The sentinel errors (e.g. ErrPastDeadline) are matched with errors.Is.
+type AnnounceError struct {
+	Result NemAnnounceResult
+}
+
+func (e *AnnounceError) Error() string {
+	return "nis: announce failed: " + e.Result.Message + " (code " + strconv.Itoa(e.Result.Code) + ")"
+}
+
+// Unwrap returns the sentinel error of the failure, nil when it is not a well-known one
+func (e *AnnounceError) Unwrap() error {
+	return failure(e.Result.Code, e.Result.Message)
+}
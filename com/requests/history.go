@@ -0,0 +1,168 @@
+package requests
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+// The transactions of an account walked by a HistoryIterator
+type HistoryDirection int
+
+const (
+	// The incoming and outgoing transactions
+	AllHistory HistoryDirection = iota
+	// The transactions where the account is the recipient
+	IncomingHistory
+	// The transactions where the account is the sender
+	OutgoingHistory
+)
+
+// HistoryQuery selects the transactions walked by a HistoryIterator
+type HistoryQuery struct {
+	// Address is the account address
+	Address base.Address
+	// Direction selects the incoming, outgoing or all transactions
+	Direction HistoryDirection
+	// FromID starts the walk below this transaction id, 0 starts at the newest transaction
+	FromID int
+	// StopHeight ends the walk at the first transaction below this height, 0 for none
+	StopHeight int64
+	// StopTime ends the walk at the first transaction created before this time, zero for none
+	StopTime time.Time
+}
+
+// A HistoryIterator walks the transactions of an account from the newest to the oldest,
+// one page at a time, using the id of the last transaction of a page as the cursor
+// of the next page.
+//
+//	it := client.History(requests.HistoryQuery{Address: address})
+//	for it.Next(ctx) {
+//		fmt.Println(it.Transaction().Meta.Hash.Data)
+//	}
+//	if err := it.Err(); err != nil {
+//		fmt.Println(err)
+//	}
+type HistoryIterator struct {
+	client *Client
+	query  HistoryQuery
+	page   []TransactionMetaDataPair
+	cursor int
+	tx     TransactionMetaDataPair
+	done   bool
+	err    error
+}
+
+// Create an iterator over the transactions of an account
+// There is no HistoryCtx, the requests are bound to the ctx given to each Next.
+// param query - A HistoryQuery struct
+// return - A HistoryIterator point
+func (c *Client) History(query HistoryQuery) *HistoryIterator {
+	return &HistoryIterator{client: c, query: query, cursor: query.FromID}
+}
+
+// Advance to the next transaction, fetching the next page when needed
+// param ctx - Bounds the requests, the walk ends with ctx.Err() when done
+// return - False when the walk is over, see Err
+func (it *HistoryIterator) Next(ctx context.Context) bool {
+	if it.done {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		return it.stop(err)
+	}
+	if len(it.page) == 0 {
+		if err := it.fetch(ctx); err != nil {
+			return it.stop(err)
+		}
+		if len(it.page) == 0 {
+			return it.stop(nil)
+		}
+	}
+
+	it.tx, it.page = it.page[0], it.page[1:]
+	if it.query.StopHeight > 0 && it.tx.Meta.Height < it.query.StopHeight {
+		return it.stop(nil)
+	}
+	if !it.query.StopTime.IsZero() && it.tx.Transaction != nil {
+		stamp := it.tx.Transaction.GetCommon().TimeStamp
+		if stamp != nil && *stamp < utils.ToNEMTimeStamp(it.query.StopTime) {
+			return it.stop(nil)
+		}
+	}
+	return true
+}
+
+// Gets the current transaction
+// return - A [TransactionMetaDataPair] struct
+func (it *HistoryIterator) Transaction() TransactionMetaDataPair {
+	return it.tx
+}
+
+// Gets the error which ended the walk, nil when the walk reached its end
+func (it *HistoryIterator) Err() error {
+	return it.err
+}
+
+func (it *HistoryIterator) stop(err error) bool {
+	it.done, it.err = true, err
+	it.tx, it.page = TransactionMetaDataPair{}, nil
+	return false
+}
+
+// Fetch the page following the cursor
+func (it *HistoryIterator) fetch(ctx context.Context) error {
+	var id string
+	if it.cursor > 0 {
+		id = strconv.Itoa(it.cursor)
+	}
+
+	var page []TransactionMetaDataPair
+	var err error
+	switch it.query.Direction {
+	case IncomingHistory:
+		page, err = it.client.IncomingTransactionsCtx(ctx, it.query.Address, "", id)
+	case OutgoingHistory:
+		page, err = it.client.OutgoingTransactionsCtx(ctx, it.query.Address, "", id)
+	default:
+		page, err = it.client.AllTransactionsCtx(ctx, it.query.Address, "", id)
+	}
+	if err != nil {
+		return err
+	}
+	if len(page) == 0 {
+		it.page = nil
+		return nil
+	}
+
+	// The ids decrease, a page not below the cursor would repeat forever
+	last := page[len(page)-1].Meta.ID
+	if last <= 0 || it.cursor > 0 && last >= it.cursor {
+		return errors.New("history: the node returned a page above the cursor")
+	}
+	it.cursor, it.page = last, page
+	return nil
+}
+
+// Call fn with every transaction of an account, from the newest to the oldest
+// param query - A HistoryQuery struct
+// param fn - Called with each transaction, the walk ends when it returns an error
+// return - The error of fn or of a request, nil when the walk reached its end
+func (c *Client) WalkHistory(query HistoryQuery, fn func(TransactionMetaDataPair) error) error {
+	return c.WalkHistoryCtx(context.Background(), query, fn)
+}
+
+// Same as WalkHistory, the requests are bound to ctx
+func (c *Client) WalkHistoryCtx(ctx context.Context, query HistoryQuery, fn func(TransactionMetaDataPair) error) error {
+	it := c.History(query)
+	for it.Next(ctx) {
+		if err := fn(it.Transaction()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
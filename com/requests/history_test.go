@@ -0,0 +1,231 @@
+package requests_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/com/requests/nistest"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+// The number of transfers of the test history, more than two pages
+const historySize = 2*nistest.PageSize + 10
+
+// The time of the transfer at height i
+func historyTime(i int) time.Time {
+	return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(i) * time.Minute)
+}
+
+// A node with historySize transfers from sender to recipient, the transfer at height i has the id i
+func newHistoryNode(t *testing.T) (srv *nistest.Server, sender, recipient base.Address) {
+	kp, err := model.KeyPairCreate("0e0f2b5d5d2a1e9ba3d4dd8f1bb6e0be5b7c8f1c29e1b9b3a55f23d44a8fa4d1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender, err = utils.PubToAddress(kp.PublicString(), model.Data.Testnet.ID); err != nil {
+		t.Fatal(err)
+	}
+	recipient = base.MustParseAddress("TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S")
+
+	srv = nistest.NewServer()
+	t.Cleanup(srv.Close)
+	for i := 1; i <= historySize; i++ {
+		timeStamp := utils.ToNEMTimeStamp(historyTime(i))
+		deadline := timeStamp + 3600
+		srv.SetHeight(int64(i))
+		srv.AddTransaction(&base.TransferTransaction{
+			CommonTransaction: base.CommonTransaction{
+				Type:      model.Transfer,
+				Version:   model.GetVersion(1, model.Data.Testnet.ID),
+				Signer:    kp.PublicString(),
+				TimeStamp: &timeStamp,
+				Fee:       50000,
+				Deadline:  &deadline,
+			},
+			Amount:    base.Amount(i),
+			Recipient: recipient.String(),
+		})
+	}
+	return srv, sender, recipient
+}
+
+// Counts the requests of a client
+type countingTransport struct {
+	n int32
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&c.n, 1)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// Walk a query, returning the ids of the transactions
+func walk(t *testing.T, client *requests.Client, query requests.HistoryQuery) []int {
+	t.Helper()
+	var ids []int
+	it := client.History(query)
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Transaction().Meta.ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if it.Next(context.Background()) {
+		t.Error("Next after the end of the walk")
+	}
+	return ids
+}
+
+// Check the ids go down from first to last, one by one
+func checkIDs(t *testing.T, ids []int, first, last int) {
+	t.Helper()
+	if len(ids) != first-last+1 {
+		t.Fatalf("%d transactions, want %d (ids %d to %d)", len(ids), first-last+1, first, last)
+	}
+	for i, id := range ids {
+		if id != first-i {
+			t.Fatalf("transaction %d has the id %d, want %d", i, id, first-i)
+		}
+	}
+}
+
+func TestHistoryPagination(t *testing.T) {
+	srv, sender, recipient := newHistoryNode(t)
+	transport := &countingTransport{}
+	client := srv.Client(requests.WithTransport(transport))
+
+	checkIDs(t, walk(t, client, requests.HistoryQuery{Address: recipient}), historySize, 1)
+	// Three full or partial pages and the empty page ending the walk
+	if transport.n != 4 {
+		t.Errorf("%d requests, want 4", transport.n)
+	}
+
+	checkIDs(t, walk(t, client, requests.HistoryQuery{Address: recipient, Direction: requests.IncomingHistory}), historySize, 1)
+	checkIDs(t, walk(t, client, requests.HistoryQuery{Address: sender, Direction: requests.OutgoingHistory}), historySize, 1)
+	if ids := walk(t, client, requests.HistoryQuery{Address: recipient, Direction: requests.OutgoingHistory}); len(ids) != 0 {
+		t.Errorf("%d outgoing transactions of the recipient, want 0", len(ids))
+	}
+	checkIDs(t, walk(t, client, requests.HistoryQuery{Address: recipient, FromID: 30}), 29, 1)
+
+	// Every transaction is decoded
+	it := client.History(requests.HistoryQuery{Address: recipient})
+	if !it.Next(context.Background()) {
+		t.Fatal(it.Err())
+	}
+	tx, ok := it.Transaction().Transaction.(*base.TransactionMosaic)
+	if !ok || tx.Amount != historySize || it.Transaction().Meta.Height != historySize {
+		t.Errorf("first transaction = %+v", it.Transaction())
+	}
+}
+
+func TestHistoryStop(t *testing.T) {
+	srv, _, recipient := newHistoryNode(t)
+	client := srv.Client()
+
+	checkIDs(t, walk(t, client, requests.HistoryQuery{Address: recipient, StopHeight: 41}), historySize, 41)
+	checkIDs(t, walk(t, client, requests.HistoryQuery{Address: recipient, StopTime: historyTime(12)}), historySize, 12)
+	// The first of the two bounds ends the walk
+	checkIDs(t, walk(t, client, requests.HistoryQuery{Address: recipient, StopHeight: 20, StopTime: historyTime(30)}), historySize, 30)
+	if ids := walk(t, client, requests.HistoryQuery{Address: recipient, StopHeight: historySize + 1}); len(ids) != 0 {
+		t.Errorf("%d transactions above the chain height, want 0", len(ids))
+	}
+}
+
+func TestWalkHistory(t *testing.T) {
+	srv, _, recipient := newHistoryNode(t)
+	client := srv.Client()
+
+	var ids []int
+	err := client.WalkHistory(requests.HistoryQuery{Address: recipient}, func(p requests.TransactionMetaDataPair) error {
+		ids = append(ids, p.Meta.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkIDs(t, ids, historySize, 1)
+
+	// An error of fn ends the walk
+	stop := errors.New("stop")
+	n := 0
+	err = client.WalkHistory(requests.HistoryQuery{Address: recipient}, func(p requests.TransactionMetaDataPair) error {
+		if n++; n == 30 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || n != 30 {
+		t.Errorf("err = %v after %d transactions, want stop after 30", err, n)
+	}
+}
+
+func TestHistoryCancel(t *testing.T) {
+	srv, _, recipient := newHistoryNode(t)
+	client := srv.Client()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	it := client.History(requests.HistoryQuery{Address: recipient})
+	n := 0
+	for it.Next(ctx) {
+		if n++; n == 10 {
+			cancel()
+		}
+	}
+	if !errors.Is(it.Err(), context.Canceled) || n != 10 {
+		t.Errorf("err = %v after %d transactions, want context.Canceled after 10", it.Err(), n)
+	}
+	if it.Transaction().Transaction != nil {
+		t.Error("a transaction is current after the end of the walk")
+	}
+
+	err := client.WalkHistoryCtx(ctx, requests.HistoryQuery{Address: recipient}, func(requests.TransactionMetaDataPair) error {
+		t.Error("fn called with a canceled ctx")
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("WalkHistoryCtx: err = %v, want context.Canceled", err)
+	}
+}
+
+// A node answering the same page to every request never ends a walk
+func TestHistoryPageAboveCursor(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data string
+		for _, id := range []int{5, 4} {
+			if data != "" {
+				data += ","
+			}
+			data += fmt.Sprintf(`{"meta":{"id":%d,"height":1,"hash":{"data":""}},"transaction":{"type":2049,"mode":1}}`, id)
+		}
+		fmt.Fprintf(w, `{"data":[%s]}`, data)
+	}))
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, _ := strconv.Atoi(u.Port())
+	client := requests.NewClient(base.Node{Host: "http://" + u.Hostname(), Port: port})
+
+	var ids []int
+	err = client.WalkHistory(requests.HistoryQuery{Address: base.MustParseAddress("TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S")},
+		func(p requests.TransactionMetaDataPair) error {
+			ids = append(ids, p.Meta.ID)
+			return nil
+		})
+	if err == nil || len(ids) != 2 {
+		t.Errorf("err = %v after the ids %v, want the page above the cursor error after 5 and 4", err, ids)
+	}
+}
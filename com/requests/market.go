@@ -1,13 +1,11 @@
 package requests
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"github.com/isarq/nem-sdk-go/model"
-	"io/ioutil"
 	"net/http"
 	"strings"
-	"time"
 )
 
 type MarketInfo struct {
@@ -1349,34 +1347,27 @@ type MarketInfoBtcPrice struct {
 // Gets market information from Poloniex api
 // return {struct} - A MarketInfo struct
 func Xem() (MarketInfo, error) {
+	return XemCtx(context.Background())
+}
+
+// Same as Xem, the request is bound to ctx
+func XemCtx(ctx context.Context) (MarketInfo, error) {
 	c := Client{}
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
 	node := strings.Split(model.MarketInfo, "//")
 	node = strings.Split(node[1], "/")
 	//port := node[1]
 	c.URL.Host = node[0]
-	c.URL.Path = "/public"
 	c.URL.Scheme = "https"
 	params := map[string]string{"command": "returnTicker"}
-	req, err := c.buildReq(params, nil, http.MethodGet)
+	req, err := c.buildReq("/public", params, nil, http.MethodGet)
 	if err != nil {
 		return MarketInfo{}, err
 	}
 
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return MarketInfo{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return MarketInfo{}, err
-	}
 
 	var data MarketInfo
 	if err := json.Unmarshal(byteArray, &data); err != nil {
@@ -1388,34 +1379,27 @@ func Xem() (MarketInfo, error) {
 // Gets BTC price from blockchain.info API
 // return {object} - A MarketInfo object
 func Btc() (MarketInfoBtcPrice, error) {
+	return BtcCtx(context.Background())
+}
+
+// Same as Btc, the request is bound to ctx
+func BtcCtx(ctx context.Context) (MarketInfoBtcPrice, error) {
 	c := Client{}
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
 	node := strings.Split(model.BtcPrice, "//")
 	node = strings.Split(node[1], "/")
 	//port := node[1]
 	c.URL.Host = node[0]
-	c.URL.Path = "/ticker"
 	c.URL.Scheme = "https"
 	params := map[string]string{"cors": "true"}
-	req, err := c.buildReq(params, nil, http.MethodGet)
+	req, err := c.buildReq("/ticker", params, nil, http.MethodGet)
 	if err != nil {
 		return MarketInfoBtcPrice{}, err
 	}
 
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return MarketInfoBtcPrice{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return MarketInfoBtcPrice{}, err
-	}
 
 	var data MarketInfoBtcPrice
 	if err := json.Unmarshal(byteArray, &data); err != nil {
@@ -1,13 +1,11 @@
 package requests
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/isarq/nem-sdk-go/base"
-	"io/ioutil"
 	"net/http"
-	"time"
 )
 
 type ID struct {
@@ -32,26 +30,19 @@ type MosaicDefinitionMetaDataPair struct {
 // param id - A mosaic id
 // return - An mosaicSupplyInfo struct
 func (c *Client) Supply(id string) (MosaicSupplyInfo, error) {
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/mosaic/supply"
-	req, err := c.buildReq(map[string]string{"mosaicId": id}, nil, http.MethodGet)
+	return c.SupplyCtx(context.Background(), id)
+}
+
+// Same as Supply, the request is bound to ctx
+func (c *Client) SupplyCtx(ctx context.Context, id string) (MosaicSupplyInfo, error) {
+	req, err := c.buildReq("/mosaic/supply", map[string]string{"mosaicId": id}, nil, http.MethodGet)
 	if err != nil {
 		return MosaicSupplyInfo{}, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return MosaicSupplyInfo{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return MosaicSupplyInfo{}, err
-	}
 
 	var data MosaicSupplyInfo
 	if err := json.Unmarshal(byteArray, &data); err != nil {
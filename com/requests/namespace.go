@@ -1,15 +1,13 @@
 package requests
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/isarq/nem-sdk-go/base"
 	"github.com/isarq/nem-sdk-go/utils"
-	"io/ioutil"
 	"net/http"
 	"strconv"
-	"time"
 )
 
 // A namespace consists of a namespace object and a database id.
@@ -39,33 +37,26 @@ type Namespace struct {
 // return - An slice of [NamespaceMetaDataPair] struct
 // link http://bob.nem.ninja/docs/#namespaceMetaDataPair
 func (c *Client) NameSpaceRoots(id int) ([]NamespaceMetaDataPair, error) {
+	return c.NameSpaceRootsCtx(context.Background(), id)
+}
+
+// Same as NameSpaceRoots, the request is bound to ctx
+func (c *Client) NameSpaceRootsCtx(ctx context.Context, id int) ([]NamespaceMetaDataPair, error) {
 
 	params := map[string]string{"pageSize": "100"}
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
 	if id != 0 {
 		Id := strconv.Itoa(id)
 		params["id"] = Id
 	}
 
-	c.URL.Path = "/namespace/root/page"
-	req, err := c.buildReq(params, nil, http.MethodGet)
+	req, err := c.buildReq("/namespace/root/page", params, nil, http.MethodGet)
 	if err != nil {
 		return []NamespaceMetaDataPair{}, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return []NamespaceMetaDataPair{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []NamespaceMetaDataPair{}, err
-	}
 
 	var data = struct{ Data []NamespaceMetaDataPair }{}
 	if err := json.Unmarshal(byteArray, &data); err != nil {
@@ -81,27 +72,20 @@ func (c *Client) NameSpaceRoots(id int) ([]NamespaceMetaDataPair, error) {
 // return - An slice of [MosaicDefinition] struct
 // link http://bob.nem.ninja/docs/#mosaicDefinition
 func (c *Client) MosaicDefinitions(id string) ([]MosaicDefinitionMetaDataPair, error) {
+	return c.MosaicDefinitionsCtx(context.Background(), id)
+}
+
+// Same as MosaicDefinitions, the request is bound to ctx
+func (c *Client) MosaicDefinitionsCtx(ctx context.Context, id string) ([]MosaicDefinitionMetaDataPair, error) {
 	params := map[string]string{"namespace": id}
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/namespace/mosaic/definition/page"
-	req, err := c.buildReq(params, nil, http.MethodGet)
+	req, err := c.buildReq("/namespace/mosaic/definition/page", params, nil, http.MethodGet)
 	if err != nil {
 		return []MosaicDefinitionMetaDataPair{}, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return []MosaicDefinitionMetaDataPair{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []MosaicDefinitionMetaDataPair{}, err
-	}
 
 	var data = struct {
 		Data []MosaicDefinitionMetaDataPair
@@ -123,27 +107,20 @@ func (c *Client) MosaicDefinitions(id string) ([]MosaicDefinitionMetaDataPair, e
 // return - A [NamespaceInfo] struct
 // link http://bob.nem.ninja/docs/#namespace
 func (c *Client) Namespaceinfo(id string) (Namespace, error) {
+	return c.NamespaceinfoCtx(context.Background(), id)
+}
+
+// Same as Namespaceinfo, the request is bound to ctx
+func (c *Client) NamespaceinfoCtx(ctx context.Context, id string) (Namespace, error) {
 	params := map[string]string{"namespace": id}
-	timeout := time.Duration(10 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/namespace"
-	req, err := c.buildReq(params, nil, http.MethodGet)
+	req, err := c.buildReq("/namespace", params, nil, http.MethodGet)
 	if err != nil {
 		return Namespace{}, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return Namespace{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return Namespace{}, err
-	}
 
 	var data Namespace
 	if err := json.Unmarshal(byteArray, &data); err != nil {
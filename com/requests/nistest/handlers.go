@@ -0,0 +1,678 @@
+package nistest
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+// The NIS validation results answered to a rejected announce
+const (
+	failureUnknown             = "FAILURE_UNKNOWN"
+	failurePastDeadline        = "FAILURE_PAST_DEADLINE"
+	failureInsufficientBalance = "FAILURE_INSUFFICIENT_BALANCE"
+	failureSignature           = "FAILURE_SIGNATURE_NOT_VERIFIABLE"
+	failureWrongNetwork        = "FAILURE_WRONG_NETWORK"
+	failureCached              = "FAILURE_TRANSACTION_CACHED"
+	failureHashExists          = "FAILURE_HASH_EXISTS"
+	failureNoMultisig          = "FAILURE_MULTISIG_NO_MATCHING_MULTISIG"
+)
+
+// The codes of the validation results, 2 (FAILURE_UNKNOWN) for the others
+var failureCodes = map[string]int{
+	failureUnknown:             2,
+	failurePastDeadline:        4,
+	failureInsufficientBalance: 5,
+}
+
+const xem = "nem:xem"
+
+// The supply of nem:xem in whole units
+const xemSupply = 8999999999
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/heartbeat", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, requests.NemRequestResult{Type: 2, Code: 1, Message: "ok"})
+	})
+	mux.HandleFunc("/chain/height", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, requests.BlockHeight{Height: s.Height()})
+	})
+	mux.HandleFunc("/chain/last-block", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, s.block(s.Height()))
+	})
+	mux.HandleFunc("/block/at/public", s.blockAt)
+	mux.HandleFunc("/account/get", func(w http.ResponseWriter, r *http.Request) {
+		address := r.URL.Query().Get("address")
+		if address == "" {
+			writeError(w, http.StatusBadRequest, "address must not be empty")
+			return
+		}
+		writeJSON(w, s.Account(address))
+	})
+	mux.HandleFunc("/account/get/from-public-key", func(w http.ResponseWriter, r *http.Request) {
+		publicKey := r.URL.Query().Get("publicKey")
+		s.mu.Lock()
+		a := s.accountOf(publicKey)
+		var data requests.AccountMetaDataPair
+		if a != nil {
+			data = *a
+		}
+		s.mu.Unlock()
+		if a == nil {
+			writeError(w, http.StatusBadRequest, "invalid public key")
+			return
+		}
+		writeJSON(w, data)
+	})
+	mux.HandleFunc("/account/mosaic/owned", func(w http.ResponseWriter, r *http.Request) {
+		address := r.URL.Query().Get("address")
+		xemOwned := base.Mosaic{
+			MosaicID: base.MosaicID{NamespaceID: "nem", Name: "xem"},
+			Quantity: s.Account(address).Account.Balance,
+		}
+		writeJSON(w, struct{ Data []base.Mosaic }{append([]base.Mosaic{xemOwned}, s.Mosaics(address)...)})
+	})
+	mux.HandleFunc("/account/transfers/all", s.transfers(true, true))
+	mux.HandleFunc("/account/transfers/incoming", s.transfers(true, false))
+	mux.HandleFunc("/account/transfers/outgoing", s.transfers(false, true))
+	mux.HandleFunc("/account/unconfirmedTransactions", s.unconfirmedTransactions)
+	mux.HandleFunc("/namespace", func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("namespace")
+		s.mu.Lock()
+		ns, ok := s.namespaces[id]
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "invalid namespace '"+id+"'")
+			return
+		}
+		writeJSON(w, ns)
+	})
+	mux.HandleFunc("/namespace/mosaic/definition/page", func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("namespace")
+		s.mu.Lock()
+		data := make([]requests.MosaicDefinitionMetaDataPair, len(s.definitions[id]))
+		for i, d := range s.definitions[id] {
+			data[i] = requests.MosaicDefinitionMetaDataPair{Meta: requests.Meta{ID: i + 1}, Mosaic: d}
+		}
+		s.mu.Unlock()
+		writeJSON(w, struct {
+			Data []requests.MosaicDefinitionMetaDataPair
+		}{data})
+	})
+	mux.HandleFunc("/mosaic/supply", func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("mosaicId")
+		id := mosaicID(name)
+		supply, ok := s.Supply(id)
+		if !ok || id.NamespaceID == "" {
+			writeError(w, http.StatusBadRequest, "invalid mosaic id '"+name+"'")
+			return
+		}
+		writeJSON(w, requests.MosaicSupplyInfo{MosaicID: id, Supply: int(supply)})
+	})
+	mux.HandleFunc("/transaction/get", func(w http.ResponseWriter, r *http.Request) {
+		hash := r.URL.Query().Get("hash")
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, e := range s.confirmed {
+			if e.hash == hash {
+				writeJSON(w, e.pair())
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "Neo4j: unknown transaction hash")
+	})
+	mux.HandleFunc("/transaction/announce", s.announce)
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	data, err := nisJSON(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	data, _ := nisJSON(base.Error{
+		TimeStamp: utils.CreateNEMTimeStamp() * 1000,
+		Error:     http.StatusText(status),
+		Message:   message,
+		Status:    status,
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// Encode v with the lower camel case keys of NIS
+func nisJSON(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var tree interface{}
+	if err := d.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return json.Marshal(lowerKeys(tree))
+}
+
+func lowerKeys(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			r, n := utf8.DecodeRuneInString(k)
+			m[string(unicode.ToLower(r))+k[n:]] = lowerKeys(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range v {
+			v[i] = lowerKeys(e)
+		}
+	}
+	return v
+}
+
+type hashData struct {
+	Data string `json:"data,omitempty"`
+}
+
+// A confirmed transaction as answered by NIS
+func (e *entry) pair() interface{} {
+	return struct {
+		Meta struct {
+			InnerHash hashData `json:"innerHash"`
+			ID        int      `json:"id"`
+			Hash      hashData `json:"hash"`
+			Height    int64    `json:"height"`
+		} `json:"meta"`
+		Transaction interface{} `json:"transaction"`
+	}{
+		Meta: struct {
+			InnerHash hashData `json:"innerHash"`
+			ID        int      `json:"id"`
+			Hash      hashData `json:"hash"`
+			Height    int64    `json:"height"`
+		}{hashData{e.innerHash}, e.id, hashData{e.hash}, e.height},
+		Transaction: e.transaction(),
+	}
+}
+
+// The transaction with its signature
+func (e *entry) transaction() interface{} {
+	data, err := nisJSON(e.tx)
+	if err != nil {
+		return nil
+	}
+	var m map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	d.Decode(&m)
+	if e.signature != "" {
+		m["signature"] = e.signature
+	}
+	return m
+}
+
+// The block at a height
+func (s *Server) block(height int64) requests.Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := requests.Block{Height: height, Type: 1, Version: s.Network<<24 | 1, TimeStamp: utils.CreateNEMTimeStamp()}
+	b.Transactions = []interface{}{}
+	for _, e := range s.confirmed {
+		if e.height == height {
+			b.Transactions = append(b.Transactions, e.transaction())
+		}
+	}
+	return b
+}
+
+func (s *Server) blockAt(w http.ResponseWriter, r *http.Request) {
+	var req struct{ Height int64 }
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Height < 1 {
+		writeError(w, http.StatusBadRequest, "invalid height")
+		return
+	}
+	if req.Height > s.Height() {
+		writeError(w, http.StatusNotFound, "block "+strconv.FormatInt(req.Height, 10)+" not found")
+		return
+	}
+	writeJSON(w, s.block(req.Height))
+}
+
+// Report if a transaction is sent from or to an account. The lock must be held.
+func (s *Server) involves(tx base.Transaction, address string, incoming, outgoing bool) bool {
+	if outgoing && s.addressOf(tx.GetCommon().Signer) == address {
+		return true
+	}
+	switch t := tx.(type) {
+	case *base.MultiSignTransaction:
+		if inner, ok := t.OtherTrans.(base.Transaction); ok {
+			return s.involves(inner, address, incoming, outgoing)
+		}
+	case *base.TransferTransaction:
+		return incoming && normalize(t.Recipient) == address
+	}
+	return false
+}
+
+func (s *Server) addressOf(publicKey string) string {
+	address, _ := model.ToAddress(publicKey, s.Network)
+	return address
+}
+
+// Serve a page of the history of an account, from the newest transaction below the id
+func (s *Server) transfers(incoming, outgoing bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address := normalize(r.URL.Query().Get("address"))
+		below := -1
+		if id := r.URL.Query().Get("id"); id != "" {
+			var err error
+			if below, err = strconv.Atoi(id); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid id")
+				return
+			}
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		var entries []*entry
+		for _, e := range s.confirmed {
+			if (below < 0 || e.id < below) && s.involves(e.tx, address, incoming, outgoing) {
+				entries = append(entries, e)
+			}
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].id > entries[j].id })
+		if len(entries) > PageSize {
+			entries = entries[:PageSize]
+		}
+		data := make([]interface{}, len(entries))
+		for i, e := range entries {
+			data[i] = e.pair()
+		}
+		writeJSON(w, struct{ Data []interface{} }{data})
+	}
+}
+
+// Serve the unconfirmed transactions of an account, including the multisig
+// transactions waiting for the signature of a cosignatory
+func (s *Server) unconfirmedTransactions(w http.ResponseWriter, r *http.Request) {
+	address := normalize(r.URL.Query().Get("address"))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data := []interface{}{}
+	for _, e := range s.unconfirmed {
+		if !s.involves(e.tx, address, true, true) && !s.cosignatoryOf(e.tx, address) {
+			continue
+		}
+		var meta struct {
+			Data *string `json:"data"`
+		}
+		if e.innerHash != "" {
+			meta.Data = &e.innerHash
+		}
+		data = append(data, struct {
+			Meta        interface{} `json:"meta"`
+			Transaction interface{} `json:"transaction"`
+		}{meta, e.transaction()})
+	}
+	writeJSON(w, struct{ Data []interface{} }{data})
+}
+
+// Report if an address is a cosignatory of the account initiating a multisig transaction.
+// The lock must be held.
+func (s *Server) cosignatoryOf(tx base.Transaction, address string) bool {
+	m, ok := tx.(*base.MultiSignTransaction)
+	if !ok {
+		return false
+	}
+	inner, ok := m.OtherTrans.(base.Transaction)
+	if !ok {
+		return false
+	}
+	for _, c := range s.account(s.addressOf(inner.GetCommon().Signer)).Meta.Cosignatories {
+		if normalize(c.Address) == address {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) announce(w http.ResponseWriter, r *http.Request) {
+	var req requests.RequestAnnounce
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	data, err := hex.DecodeString(req.Data)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid data")
+		return
+	}
+	signature, err := hex.DecodeString(req.Signature)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid signature")
+		return
+	}
+	tx, err := utils.DeserializeTransaction(data)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	common := tx.GetCommon()
+	signer, err := hex.DecodeString(common.Signer)
+	if err != nil || len(signer) != 32 || len(signature) != 64 || !model.Verify(signer, data, signature) {
+		writeAnnounce(w, failureSignature, "", "")
+		return
+	}
+	if int(int8(uint32(common.Version)>>24)) != s.Network {
+		writeAnnounce(w, failureWrongNetwork, "", "")
+		return
+	}
+	if common.Deadline != nil && *common.Deadline < utils.CreateNEMTimeStamp() {
+		writeAnnounce(w, failurePastDeadline, "", "")
+		return
+	}
+
+	e := newEntry(tx, req.Signature)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, known := range s.confirmed {
+		if known.hash == e.hash {
+			writeAnnounce(w, failureHashExists, "", "")
+			return
+		}
+	}
+	for _, known := range s.unconfirmed {
+		if known.hash == e.hash {
+			writeAnnounce(w, failureCached, "", "")
+			return
+		}
+	}
+	if !s.covered(tx) {
+		writeAnnounce(w, failureInsufficientBalance, "", "")
+		return
+	}
+
+	// A cosignature is attached to the pending multisig transaction
+	if c, ok := tx.(*base.MultisigSignatureTransaction); ok {
+		for _, pending := range s.unconfirmed {
+			if pending.innerHash == c.OtherHash.Data {
+				m := pending.tx.(*base.MultiSignTransaction)
+				m.Signatures = append(m.Signatures, cosignature(c))
+				writeAnnounce(w, "", e.hash, "")
+				return
+			}
+		}
+		writeAnnounce(w, failureNoMultisig, "", "")
+		return
+	}
+
+	s.unconfirmed = append(s.unconfirmed, e)
+	writeAnnounce(w, "", e.hash, e.innerHash)
+}
+
+func writeAnnounce(w http.ResponseWriter, failure, hash, innerHash string) {
+	result := requests.NemAnnounceResult{Type: 1, Code: 1, Message: "SUCCESS"}
+	if failure != "" {
+		result.Message = failure
+		if result.Code = failureCodes[failure]; result.Code == 0 {
+			result.Code = failureCodes[failureUnknown]
+		}
+	}
+	result.TransactionHash.Data = hash
+	result.InnerTransactionHash.Data = innerHash
+	writeJSON(w, result)
+}
+
+func cosignature(c *base.MultisigSignatureTransaction) base.MultiSignSignatureTransaction {
+	s := base.MultiSignSignatureTransaction{
+		Fee:          c.Fee,
+		Type:         c.Type,
+		Version:      c.Version,
+		Signer:       c.Signer,
+		OtherAccount: c.OtherAccount,
+	}
+	s.OtherHash.Data = c.OtherHash.Data
+	if c.TimeStamp != nil {
+		s.TimeStamp = *c.TimeStamp
+	}
+	if c.Deadline != nil {
+		s.Deadline = *c.Deadline
+	}
+	return s
+}
+
+// The amounts an account pays for a transaction, by account and by mosaic
+type debits map[string]map[string]base.Amount
+
+func (d debits) add(address, mosaic string, amount base.Amount) {
+	if d[address] == nil {
+		d[address] = make(map[string]base.Amount)
+	}
+	d[address][mosaic] += amount
+}
+
+// Collect the amounts paid for a transaction. The lock must be held.
+func (s *Server) debits(tx base.Transaction, d debits) {
+	signer := s.addressOf(tx.GetCommon().Signer)
+	address, _ := model.Debtor(tx, s.Network)
+	debtor := address.String()
+	d.add(debtor, xem, tx.GetCommon().Fee)
+
+	switch t := tx.(type) {
+	case *base.MultiSignTransaction:
+		if inner, ok := t.OtherTrans.(base.Transaction); ok {
+			s.debits(inner, d)
+		}
+		for _, c := range t.Signatures {
+			d.add(debtor, xem, c.Fee)
+		}
+	case *base.TransferTransaction:
+		for mosaic, q := range transferred(t) {
+			d.add(signer, mosaic, q)
+		}
+		for _, l := range s.levies(t) {
+			d.add(signer, utils.MosaicIdToName(l.Levy.MosaicID), l.Quantity)
+		}
+	case *base.ProvisionNamespaceTransaction:
+		d.add(signer, xem, t.RentalFee)
+	case *base.MosaicDefinitionCreationTransaction:
+		d.add(signer, xem, t.CreationFee)
+	}
+}
+
+// The quantities moved by a transfer, by mosaic
+func transferred(t *base.TransferTransaction) map[string]base.Amount {
+	if len(t.Mosaics) == 0 {
+		return map[string]base.Amount{xem: t.Amount}
+	}
+	// The amount is a multiplier of the mosaic quantities, 1000000 for the quantities as given
+	moved := make(map[string]base.Amount)
+	for _, m := range t.Mosaics {
+		moved[utils.MosaicIdToName(m.MosaicID)] += model.TransferredQuantity(t.Amount, m.Quantity)
+	}
+	return moved
+}
+
+// The levies of the mosaics of a transfer, by the definitions of the server. The lock must be held.
+func (s *Server) levies(t *base.TransferTransaction) []base.MosaicLevy {
+	var levies []base.MosaicLevy
+	for _, m := range t.Mosaics {
+		for _, d := range s.definitions[m.MosaicID.NamespaceID] {
+			if d.ID == m.MosaicID && d.Levy.Type != 0 {
+				q := model.CalculateLevy(d.Levy, model.TransferredQuantity(t.Amount, m.Quantity))
+				levies = append(levies, base.MosaicLevy{Mosaic: m.MosaicID, Levy: d.Levy, Quantity: q})
+			}
+		}
+	}
+	return levies
+}
+
+// Report if the accounts own what they pay for a transaction. The lock must be held.
+func (s *Server) covered(tx base.Transaction) bool {
+	d := debits{}
+	s.debits(tx, d)
+	for address, mosaics := range d {
+		for mosaic, amount := range mosaics {
+			if s.owned(address, mosaic) < amount {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// The quantity of a mosaic owned by an account. The lock must be held.
+func (s *Server) owned(address, mosaic string) base.Amount {
+	if mosaic == xem {
+		return s.account(address).Account.Balance
+	}
+	for _, m := range s.mosaics[address] {
+		if utils.MosaicIdToName(m.MosaicID) == mosaic {
+			return m.Quantity
+		}
+	}
+	return 0
+}
+
+// Change the quantity of a mosaic owned by an account. The lock must be held.
+func (s *Server) credit(address string, id base.MosaicID, amount base.Amount, debit bool) {
+	name := utils.MosaicIdToName(id)
+	if name == xem {
+		a := s.account(address)
+		if debit {
+			a.Account.Balance -= amount
+		} else {
+			a.Account.Balance += amount
+		}
+		return
+	}
+	for i, m := range s.mosaics[address] {
+		if utils.MosaicIdToName(m.MosaicID) == name {
+			if debit {
+				s.mosaics[address][i].Quantity -= amount
+			} else {
+				s.mosaics[address][i].Quantity += amount
+			}
+			return
+		}
+	}
+	if !debit {
+		s.mosaics[address] = append(s.mosaics[address], base.Mosaic{MosaicID: id, Quantity: amount})
+	}
+}
+
+// Apply the balance and state changes of a confirmed transaction. The lock must be held.
+func (s *Server) apply(tx base.Transaction) {
+	d := debits{}
+	s.debits(tx, d)
+	for address, mosaics := range d {
+		for mosaic, amount := range mosaics {
+			s.credit(address, mosaicID(mosaic), amount, true)
+		}
+	}
+
+	if m, ok := tx.(*base.MultiSignTransaction); ok {
+		if inner, ok := m.OtherTrans.(base.Transaction); ok {
+			s.applyCredits(inner)
+		}
+		return
+	}
+	s.applyCredits(tx)
+}
+
+// Apply the credits of a transaction. The lock must be held.
+func (s *Server) applyCredits(tx base.Transaction) {
+	signer := s.addressOf(tx.GetCommon().Signer)
+	switch t := tx.(type) {
+	case *base.TransferTransaction:
+		for mosaic, q := range transferred(t) {
+			s.credit(normalize(t.Recipient), mosaicID(mosaic), q, false)
+		}
+		for _, l := range s.levies(t) {
+			s.credit(normalize(l.Levy.Recipient), l.Levy.MosaicID, l.Quantity, false)
+		}
+	case *base.ProvisionNamespaceTransaction:
+		fqn := t.NewPart
+		if t.Parent != "" {
+			fqn = t.Parent + "." + t.NewPart
+		}
+		s.namespaces[fqn] = requests.Namespace{Fqn: fqn, Owner: signer, Height: s.height}
+		s.credit(normalize(t.RentalFeeSink), mosaicID(xem), t.RentalFee, false)
+	case *base.MosaicDefinitionCreationTransaction:
+		s.addDefinition(t.MosaicDefinition)
+		s.credit(normalize(t.CreationFeeSink), mosaicID(xem), t.CreationFee, false)
+		supply, _ := strconv.ParseUint(utils.Grep(t.MosaicDefinition.Properties)["initialSupply"], 10, 64)
+		s.credit(signer, t.MosaicDefinition.ID, s.units(t.MosaicDefinition.ID, supply), false)
+	case *base.MosaicSupplyChangeTransaction:
+		s.credit(signer, t.MosaicID, s.units(t.MosaicID, t.Delta), t.SupplyType == 2)
+		if name := utils.MosaicIdToName(t.MosaicID); t.SupplyType == 2 {
+			s.supplies[name] -= t.Delta
+		} else {
+			s.supplies[name] += t.Delta
+		}
+	case *base.MultisigAggregateModificationTransaction:
+		a := s.account(signer)
+		for _, m := range t.Modifications {
+			cosignatory := s.accountOf(m.CosignatoryAccount).Account
+			if m.ModificationType == 1 {
+				a.Meta.Cosignatories = append(a.Meta.Cosignatories, cosignatory)
+				continue
+			}
+			for i, c := range a.Meta.Cosignatories {
+				if c.Address == cosignatory.Address {
+					a.Meta.Cosignatories = append(a.Meta.Cosignatories[:i], a.Meta.Cosignatories[i+1:]...)
+					break
+				}
+			}
+		}
+		a.Account.MultisigInfo.CosignatoriesCount = len(a.Meta.Cosignatories)
+		if t.MinCosignatories != nil {
+			a.Account.MultisigInfo.MinCosignatories += t.MinCosignatories.RelativeChange
+		}
+	}
+}
+
+// The quantity in smallest units of whole mosaic units. The lock must be held.
+func (s *Server) units(id base.MosaicID, whole uint64) base.Amount {
+	q := base.Amount(whole)
+	for _, d := range s.definitions[id.NamespaceID] {
+		if d.ID == id {
+			divisibility, _ := strconv.Atoi(utils.Grep(d.Properties)["divisibility"])
+			for i := 0; i < divisibility; i++ {
+				q *= 10
+			}
+		}
+	}
+	return q
+}
+
+func mosaicID(name string) base.MosaicID {
+	for i := len(name) - 1; i >= 0; i-- {
+		if name[i] == ':' {
+			return base.MosaicID{NamespaceID: name[:i], Name: name[i+1:]}
+		}
+	}
+	return base.MosaicID{Name: name}
+}
@@ -0,0 +1,227 @@
+package nistest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/com/requests/nistest"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/model/transactions"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+const (
+	senderKey    = "0e0f2b5d5d2a1e9ba3d4dd8f1bb6e0be5b7c8f1c29e1b9b3a55f23d44a8fa4d1"
+	recipientKey = "6a858fb93e0202fa62f894e591478caa23b06f90471e7976c30fb95efda4b312"
+)
+
+func address(t *testing.T, privateKey string) base.Address {
+	kp, err := model.KeyPairCreate(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	address, err := utils.PubToAddress(kp.PublicString(), model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return address
+}
+
+func prepare(t *testing.T, amount base.Amount, recipient base.Address) base.Transaction {
+	tx := transactions.Transfer{Amount: amount, Recipient: recipient}
+	prepared, err := tx.Prepare(transactions.Common{PrivateKey: senderKey}, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return prepared
+}
+
+func TestAnnounceAndConfirm(t *testing.T) {
+	srv := nistest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	sender, recipient := address(t, senderKey), address(t, recipientKey)
+	srv.SetAccount(requests.AccountInfo{Address: sender.String(), Balance: 100 * base.XEM})
+
+	tx := prepare(t, 10*base.XEM, recipient)
+	result, err := transactions.Send(transactions.Common{PrivateKey: senderKey}, tx, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Code != 1 || result.TransactionHash.Data == "" {
+		t.Fatalf("announce result = %+v", result)
+	}
+	if n := len(srv.Unconfirmed()); n != 1 {
+		t.Fatalf("%d unconfirmed transactions, want 1", n)
+	}
+
+	unconfirmed, err := client.UnconfirmedTransactions(sender)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unconfirmed) != 1 {
+		t.Fatalf("%d unconfirmed transactions of the sender, want 1", len(unconfirmed))
+	}
+
+	if height := srv.Confirm(); height != 2 {
+		t.Fatalf("Confirm() = %d, want 2", height)
+	}
+	fee := tx.GetCommon().Fee
+	if got, want := srv.Account(sender.String()).Account.Balance, 90*base.XEM-fee; got != want {
+		t.Errorf("sender balance = %d, want %d", got, want)
+	}
+	account, err := client.AccountData(recipient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Account.Balance != 10*base.XEM {
+		t.Errorf("recipient balance = %d, want %d", account.Account.Balance, 10*base.XEM)
+	}
+
+	found, err := client.ByHash(result.TransactionHash.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Meta.Height != 2 || found.Transaction.GetType() != utils.Transfer {
+		t.Errorf("ByHash = %+v", found)
+	}
+
+	incoming, err := client.IncomingTransactions(recipient, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(incoming) != 1 || incoming[0].Meta.Hash.Data != result.TransactionHash.Data {
+		t.Errorf("IncomingTransactions = %+v", incoming)
+	}
+
+	_, err = transactions.Send(transactions.Common{PrivateKey: senderKey}, tx, client)
+	if !errors.Is(err, requests.ErrDuplicateTransaction) {
+		t.Errorf("announce twice: err = %v, want ErrDuplicateTransaction", err)
+	}
+}
+
+func TestAnnounceRejected(t *testing.T) {
+	srv := nistest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	sender, recipient := address(t, senderKey), address(t, recipientKey)
+	srv.SetAccount(requests.AccountInfo{Address: sender.String(), Balance: base.XEM})
+
+	_, err := transactions.Send(transactions.Common{PrivateKey: senderKey}, prepare(t, 10*base.XEM, recipient), client)
+	if !errors.Is(err, requests.ErrInsufficientBalance) {
+		t.Errorf("err = %v, want ErrInsufficientBalance", err)
+	}
+
+	// Signed by another key than the signer of the transaction
+	kp, err := model.KeyPairCreate(recipientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := utils.SerializeTransaction(prepare(t, 1, recipient))
+	signature, err := kp.Sign(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Announce(requests.RequestAnnounce{Data: utils.Bt2Hex(data), Signature: utils.Bt2Hex(signature)})
+	if !errors.Is(err, requests.ErrInvalidSignature) {
+		t.Errorf("err = %v, want ErrInvalidSignature", err)
+	}
+
+	if n := len(srv.Unconfirmed()); n != 0 {
+		t.Errorf("%d unconfirmed transactions, want 0", n)
+	}
+}
+
+func TestMultisigFees(t *testing.T) {
+	const multisigKey, cosignerKey = "1b3a8d8e3f2a4d0c9e7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e5d", recipientKey
+	srv := nistest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	multisig, initiator, cosigner := address(t, multisigKey), address(t, senderKey), address(t, cosignerKey)
+	srv.SetAccount(requests.AccountInfo{Address: multisig.String(), Balance: 100 * base.XEM})
+
+	kp, err := model.KeyPairCreate(multisigKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := transactions.Transfer{Amount: 10 * base.XEM, Recipient: initiator, IsMultisig: true, MultisigAccount: kp.PublicString()}
+	prepared, err := tx.Prepare(transactions.Common{PrivateKey: senderKey}, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The initiator and the cosigner own no XEM
+	if _, err := transactions.Send(transactions.Common{PrivateKey: senderKey}, prepared, client); err != nil {
+		t.Fatal(err)
+	}
+	cosignerKP, err := model.KeyPairCreate(cosignerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending, err := transactions.PendingSignatures(client, multisig, cosignerKP.PublicString())
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("PendingSignatures = %+v, %v", pending, err)
+	}
+	if _, err := transactions.Cosign(transactions.Common{PrivateKey: cosignerKey}, pending[0], client, model.Data.Testnet.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	m := srv.Unconfirmed()[0].(*base.MultiSignTransaction)
+	if len(m.Signatures) != 1 {
+		t.Fatalf("%d cosignatures, want 1", len(m.Signatures))
+	}
+	fees := m.Fee + m.OtherTrans.(base.Transaction).GetCommon().Fee + m.Signatures[0].Fee
+	srv.Confirm()
+	if got, want := srv.Account(multisig.String()).Account.Balance, 90*base.XEM-fees; got != want {
+		t.Errorf("multisig balance = %d, want %d", got, want)
+	}
+	if got := srv.Account(initiator.String()).Account.Balance; got != 10*base.XEM {
+		t.Errorf("initiator balance = %d, want the 10 XEM transferred", got)
+	}
+	if got := srv.Account(cosigner.String()).Account.Balance; got != 0 {
+		t.Errorf("cosigner balance = %d, want 0", got)
+	}
+}
+
+func TestMosaicSupply(t *testing.T) {
+	srv := nistest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	bar := base.MosaicID{NamespaceID: "foo", Name: "bar"}
+	srv.AddMosaicDefinition(base.MosaicDefinition{ID: bar, Properties: []base.Properties{{Name: "initialSupply", Value: "1000"}}})
+	for name, want := range map[string]int{"nem:xem": 8999999999, "foo:bar": 1000} {
+		supply, err := client.Supply(name)
+		if err != nil || supply.Supply != want || utils.MosaicIdToName(supply.MosaicID) != name {
+			t.Errorf("Supply(%s) = %+v, %v, want %d", name, supply, err, want)
+		}
+	}
+	srv.SetSupply(bar, 5)
+	if supply, err := client.Supply("foo:bar"); err != nil || supply.Supply != 5 {
+		t.Errorf("Supply after SetSupply = %+v, %v, want 5", supply, err)
+	}
+	for _, name := range []string{"foo:baz", "bar", ""} {
+		if _, err := client.Supply(name); err == nil {
+			t.Errorf("Supply(%q): no error", name)
+		}
+	}
+
+	// A confirmed supply change changes the supply
+	srv.SetAccount(requests.AccountInfo{Address: address(t, senderKey).String(), Balance: 100 * base.XEM})
+	change := transactions.MosaicSupply{Mosaic: bar, SupplyType: transactions.SupplyIncrease, Delta: 20}
+	prepared, err := change.Prepare(transactions.Common{PrivateKey: senderKey}, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := transactions.Send(transactions.Common{PrivateKey: senderKey}, prepared, client); err != nil {
+		t.Fatal(err)
+	}
+	srv.Confirm()
+	if supply, ok := srv.Supply(bar); !ok || supply != 25 {
+		t.Errorf("Supply after the change = %d, %v, want 25", supply, ok)
+	}
+}
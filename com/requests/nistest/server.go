@@ -0,0 +1,303 @@
+// Package nistest provides an in-process fake NIS node for testing applications
+// built on the SDK without reaching a public node.
+//
+// The server answers the chain, account, namespace, mosaic, transaction/get and
+// transaction/announce endpoints from an in-memory state set up by the test.
+// Announced transactions are deserialized and their signature is verified with
+// model.Verify, they wait as unconfirmed transactions until Confirm includes them
+// in a new block and applies their balance changes.
+//
+//	srv := nistest.NewServer()
+//	defer srv.Close()
+//	srv.SetAccount(requests.AccountInfo{Address: address, Balance: 100 * base.XEM})
+//	client := srv.Client()
+package nistest // import "github.com/isarq/nem-sdk-go/com/requests/nistest"
+
+import (
+	"encoding/hex"
+	"net"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/external/crypto/sha3"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+// The number of transactions of a page of the account history, as NIS
+const PageSize = 25
+
+// A Server is a fake NIS node, its methods are safe for concurrent use
+type Server struct {
+	*httptest.Server
+
+	// Network is the network id of the node, the announced transactions of another network are rejected
+	Network int
+
+	mu          sync.Mutex
+	height      int64
+	accounts    map[string]*requests.AccountMetaDataPair
+	mosaics     map[string][]base.Mosaic
+	namespaces  map[string]requests.Namespace
+	definitions map[string][]base.MosaicDefinition
+	supplies    map[string]uint64
+	confirmed   []*entry
+	unconfirmed []*entry
+	nextID      int
+}
+
+// A transaction known by the server
+type entry struct {
+	hash      string
+	innerHash string
+	signature string
+	height    int64
+	id        int
+	tx        base.Transaction
+	applied   bool
+}
+
+// Start a fake testnet node at height 1
+// return - A Server point, to close when done
+func NewServer() *Server {
+	s := &Server{
+		Network:     model.Data.Testnet.ID,
+		height:      1,
+		accounts:    make(map[string]*requests.AccountMetaDataPair),
+		mosaics:     make(map[string][]base.Mosaic),
+		namespaces:  make(map[string]requests.Namespace),
+		definitions: make(map[string][]base.MosaicDefinition),
+		supplies:    map[string]uint64{xem: xemSupply},
+	}
+	s.Server = httptest.NewServer(s.handler())
+	return s
+}
+
+// Gets the endpoint of the server
+// return - A NIS endpoint struct
+func (s *Server) Node() base.Node {
+	host, port, _ := net.SplitHostPort(s.Listener.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return base.Node{Host: "http://" + host, Port: p}
+}
+
+// Create a client of the server
+// param options - Options of the client (see requests.NewClient)
+// return - A client point
+func (s *Server) Client(options ...requests.ClientOption) *requests.Client {
+	return requests.NewClient(s.Node(), options...)
+}
+
+// Gets the chain height
+func (s *Server) Height() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.height
+}
+
+// Set the chain height
+// param height - The new height, the next block is height + 1
+func (s *Server) SetHeight(height int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.height = height
+}
+
+// Set the state of an account, replacing the previous one
+// param info - An AccountInfo struct, Address is required
+func (s *Server) SetAccount(info requests.AccountInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.account(info.Address).Account = info
+}
+
+// Gets the state of an account, an unknown account has no balance
+// param address - An account address
+// return - An [AccountMetaDataPair] struct
+func (s *Server) Account(address string) requests.AccountMetaDataPair {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.account(address)
+}
+
+// Set the mosaics owned by an account
+// param address - An account address
+// param mosaics - The owned mosaics
+func (s *Server) SetMosaics(address string, mosaics []base.Mosaic) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mosaics[normalize(address)] = append([]base.Mosaic(nil), mosaics...)
+}
+
+// Gets the mosaics owned by an account
+func (s *Server) Mosaics(address string) []base.Mosaic {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]base.Mosaic(nil), s.mosaics[normalize(address)]...)
+}
+
+// Add a namespace
+// param namespace - A Namespace struct, Fqn is required
+func (s *Server) AddNamespace(namespace requests.Namespace) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.namespaces[namespace.Fqn] = namespace
+}
+
+// Add a mosaic definition to its namespace, its supply is the initialSupply property
+// param definition - A MosaicDefinition struct
+func (s *Server) AddMosaicDefinition(definition base.MosaicDefinition) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addDefinition(definition)
+}
+
+// Set the supply of a mosaic
+// param id - A mosaic id
+// param supply - The supply in whole units
+func (s *Server) SetSupply(id base.MosaicID, supply uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.supplies[utils.MosaicIdToName(id)] = supply
+}
+
+// Gets the supply of a mosaic
+// return - The supply in whole units and false when the mosaic is unknown
+func (s *Server) Supply(id base.MosaicID) (uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	supply, ok := s.supplies[utils.MosaicIdToName(id)]
+	return supply, ok
+}
+
+// Add a mosaic definition with its initial supply. The lock must be held.
+func (s *Server) addDefinition(definition base.MosaicDefinition) {
+	ns := definition.ID.NamespaceID
+	s.definitions[ns] = append(s.definitions[ns], definition)
+	supply, _ := strconv.ParseUint(utils.Grep(definition.Properties)["initialSupply"], 10, 64)
+	s.supplies[utils.MosaicIdToName(definition.ID)] = supply
+}
+
+// Add a confirmed transaction at the current height, without applying it
+// param tx - A transaction
+// return - The hash of the transaction
+func (s *Server) AddTransaction(tx base.Transaction) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e := newEntry(tx, "")
+	e.applied = true
+	s.nextID++
+	e.height, e.id = s.height, s.nextID
+	s.confirmed = append(s.confirmed, e)
+	return e.hash
+}
+
+// Gets the unconfirmed transactions, in the order they were announced
+func (s *Server) Unconfirmed() []base.Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	txs := make([]base.Transaction, len(s.unconfirmed))
+	for i, e := range s.unconfirmed {
+		txs[i] = e.tx
+	}
+	return txs
+}
+
+// Include the unconfirmed transactions in a new block and apply their balance changes
+// return - The height of the new block
+func (s *Server) Confirm() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.height++
+	for _, e := range s.unconfirmed {
+		s.nextID++
+		e.height, e.id = s.height, s.nextID
+		if !e.applied {
+			s.apply(e.tx)
+			e.applied = true
+		}
+		s.confirmed = append(s.confirmed, e)
+	}
+	s.unconfirmed = nil
+	return s.height
+}
+
+// Roll back the blocks above a height, as NIS does on a fork
+// The transactions of these blocks return to the unconfirmed transactions, unless drop is set.
+// Their balance changes are kept, a later Confirm does not apply them twice.
+// param height - The height of the new last block
+// param drop - Discard the transactions of the rolled back blocks
+func (s *Server) Rollback(height int64, drop bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if height >= s.height {
+		return
+	}
+	s.height = height
+	kept := s.confirmed[:0]
+	var reverted []*entry
+	for _, e := range s.confirmed {
+		if e.height <= height {
+			kept = append(kept, e)
+			continue
+		}
+		e.height, e.id = 0, 0
+		reverted = append(reverted, e)
+	}
+	s.confirmed = kept
+	if !drop {
+		s.unconfirmed = append(reverted, s.unconfirmed...)
+	}
+}
+
+// Gets an account, creating it when unknown. The lock must be held.
+func (s *Server) account(address string) *requests.AccountMetaDataPair {
+	address = normalize(address)
+	a, ok := s.accounts[address]
+	if !ok {
+		a = &requests.AccountMetaDataPair{
+			Account: requests.AccountInfo{Address: address},
+			Meta:    requests.AccountMetaData{Status: "LOCKED", RemoteStatus: "INACTIVE"},
+		}
+		s.accounts[address] = a
+	}
+	return a
+}
+
+// Gets the account of a public key. The lock must be held.
+func (s *Server) accountOf(publicKey string) *requests.AccountMetaDataPair {
+	address, err := model.ToAddress(publicKey, s.Network)
+	if err != nil {
+		return nil
+	}
+	a := s.account(address)
+	if a.Account.PublicKey == "" {
+		a.Account.PublicKey = publicKey
+	}
+	return a
+}
+
+func normalize(address string) string {
+	return strings.ToUpper(strings.Replace(address, "-", "", -1))
+}
+
+func newEntry(tx base.Transaction, signature string) *entry {
+	e := &entry{hash: hash(tx), signature: signature, tx: tx}
+	if m, ok := tx.(*base.MultiSignTransaction); ok {
+		if inner, ok := m.OtherTrans.(base.Transaction); ok {
+			e.innerHash = hash(inner)
+		}
+	}
+	return e
+}
+
+// The hash of a transaction, the Keccak-256 of its serialized data
+func hash(tx base.Transaction) string {
+	h := sha3.NewKeccak256()
+	h.Write(utils.SerializeTransaction(tx))
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,52 @@
+package requests
+
+import (
+	"net/http"
+	"time"
+)
+
+// The timeout of the requests when no HTTP client is given
+const DefaultTimeout = 10 * time.Second
+
+var defaultHTTPClient = &http.Client{Timeout: DefaultTimeout}
+
+// A ClientOption configures a Client created with NewClient
+type ClientOption func(*Client)
+
+// Send the requests with the given HTTP client, e.g. to share connections or set TLS and proxies
+// param client - An http.Client point
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *Client) {
+		c.HTTPClient = client
+	}
+}
+
+// Send the requests through the given transport with DefaultTimeout
+// param transport - An http.RoundTripper
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.HTTPClient = &http.Client{Transport: transport, Timeout: DefaultTimeout}
+	}
+}
+
+// Send the requests with the given timeout
+// A zero timeout leaves the deadline to the context of the requests.
+// param timeout - The timeout of a request
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		client := http.Client{}
+		if c.HTTPClient != nil {
+			client = *c.HTTPClient
+		}
+		client.Timeout = timeout
+		c.HTTPClient = &client
+	}
+}
+
+// Send the given User-Agent header with the requests
+// param userAgent - A user agent
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
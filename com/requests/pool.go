@@ -0,0 +1,312 @@
+package requests
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	. "github.com/isarq/nem-sdk-go/base"
+)
+
+// The default number of blocks a node may lag behind the highest node of a pool
+const DefaultMaxHeightLag = 2
+
+var ErrNoNode = errors.New("no node in the pool")
+
+// NodeStatus is the result of the last health check of a node
+type NodeStatus struct {
+	Node Node
+	// Healthy is true when the node answered the last heartbeat and height requests
+	Healthy bool
+	// Height is the chain height of the node
+	Height int64
+	// Latency is the round trip of the heartbeat request
+	Latency time.Duration
+	// Err is the error of the last failed check or request
+	Err error
+	// CheckedAt is the time of the last check, zero when the node was never checked
+	CheckedAt time.Time
+}
+
+// A Pool spreads the requests over several NIS nodes.
+// The requests go to the healthy nodes in sync with the chain, the fastest first,
+// and fail over to the next node when a node can not be reached.
+// A Pool is safe for concurrent use, the fields must be set before use.
+type Pool struct {
+	// MaxHeightLag is the number of blocks a node may lag behind the highest node and be in sync
+	MaxHeightLag int64
+	// Broadcast is the number of nodes a transaction is announced to, 1 when zero
+	Broadcast int
+
+	clients []*Client
+	mu      sync.RWMutex
+	status  []NodeStatus
+}
+
+// Create a pool of NIS clients
+// param nodes - The NIS endpoints, e.g. model.Nodes(model.TestnetNode, model.DefaultPort)
+// param options - Options of every client (see NewClient)
+// return - A pool point
+func NewPool(nodes []Node, options ...ClientOption) *Pool {
+	p := &Pool{
+		MaxHeightLag: DefaultMaxHeightLag,
+		clients:      make([]*Client, len(nodes)),
+		status:       make([]NodeStatus, len(nodes)),
+	}
+	for i, node := range nodes {
+		p.clients[i] = NewClient(node, options...)
+		p.status[i] = NodeStatus{Node: node, Healthy: true}
+	}
+	return p
+}
+
+// Gets the status of every node
+// return - A slice of [NodeStatus] struct in the order of the nodes
+func (p *Pool) Status() []NodeStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([]NodeStatus(nil), p.status...)
+}
+
+// Check the health, the height and the latency of every node concurrently
+// param ctx - Bounds the checks, the status of a node is kept when its check is interrupted
+// return - A slice of [NodeStatus] struct in the order of the nodes
+func (p *Pool) Check(ctx context.Context) []NodeStatus {
+	var wg sync.WaitGroup
+	for i, c := range p.clients {
+		wg.Add(1)
+		go func(i int, c *Client) {
+			defer wg.Done()
+			s := check(ctx, c)
+			// A check interrupted by ctx says nothing about the node
+			if ctx.Err() != nil {
+				return
+			}
+			p.mu.Lock()
+			p.status[i] = s
+			p.mu.Unlock()
+		}(i, c)
+	}
+	wg.Wait()
+	return p.Status()
+}
+
+func check(ctx context.Context, c *Client) NodeStatus {
+	s := NodeStatus{Node: c.Node, CheckedAt: time.Now()}
+
+	start := time.Now()
+	heartbeat, err := c.HeartbeatCtx(ctx)
+	s.Latency = time.Since(start)
+	if err != nil {
+		s.Err = err
+		return s
+	}
+	if heartbeat.Code != 1 {
+		s.Err = errors.New("heartbeat: " + heartbeat.Message)
+		return s
+	}
+
+	height, err := c.HeightCtx(ctx)
+	if err != nil {
+		s.Err = err
+		return s
+	}
+	s.Height = height.Height
+	s.Healthy = true
+	return s
+}
+
+// Check the nodes every interval until ctx is done
+// param ctx - Stops the checks
+// param interval - The delay between two checks
+func (p *Pool) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		p.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// The clients in the order they are tried: the healthy nodes in sync sorted by latency,
+// then the healthy nodes behind sorted by height. The unhealthy nodes are only tried
+// when no node is healthy.
+func (p *Pool) ranked() []int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var top int64
+	for _, s := range p.status {
+		if s.Healthy && s.Height > top {
+			top = s.Height
+		}
+	}
+	inSync := func(s NodeStatus) bool {
+		return s.Height+p.MaxHeightLag >= top
+	}
+
+	var healthy, unhealthy []int
+	for i, s := range p.status {
+		if s.Healthy {
+			healthy = append(healthy, i)
+		} else {
+			unhealthy = append(unhealthy, i)
+		}
+	}
+	sort.SliceStable(healthy, func(a, b int) bool {
+		sa, sb := p.status[healthy[a]], p.status[healthy[b]]
+		if inSync(sa) != inSync(sb) {
+			return inSync(sa)
+		}
+		if !inSync(sa) {
+			return sa.Height > sb.Height
+		}
+		return sa.Latency < sb.Latency
+	})
+	if len(healthy) == 0 {
+		return unhealthy
+	}
+	return healthy
+}
+
+// Gets the client of the best node
+// return - A client point
+func (p *Pool) Client() (*Client, error) {
+	ranked := p.ranked()
+	if len(ranked) == 0 {
+		return nil, ErrNoNode
+	}
+	return p.clients[ranked[0]], nil
+}
+
+// Call fn with the client of the best node, and with the next nodes while it fails
+// A node that can not be reached is marked unhealthy until its next check.
+// There is no fail over when NIS rejects the request, as every node would reject it.
+// param ctx - Stops the fail over when done
+// param fn - A request, e.g. func(c *Client) error { h, err = c.HeightCtx(ctx); return err }
+// return - The error of the last node tried
+func (p *Pool) Do(ctx context.Context, fn func(*Client) error) error {
+	ranked := p.ranked()
+	if len(ranked) == 0 {
+		return ErrNoNode
+	}
+	var err error
+	for _, i := range ranked {
+		if err = fn(p.clients[i]); err == nil {
+			return nil
+		}
+		if ctx.Err() != nil || rejected(err) {
+			return err
+		}
+		if unreachable(err) {
+			p.fail(i, err)
+		}
+	}
+	return err
+}
+
+// Report if NIS answered an error which does not depend on the node
+func rejected(err error) bool {
+	var announceErr *AnnounceError
+	if errors.As(err, &announceErr) {
+		return !errors.Is(err, ErrOutOfSync)
+	}
+	var nisErr *NisError
+	return errors.As(err, &nisErr) && !nisErr.Temporary()
+}
+
+// Mark a node unhealthy
+func (p *Pool) fail(i int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status[i].Healthy = false
+	p.status[i].Err = err
+}
+
+// Report if an error comes from the transport rather than from an answer of the node
+func unreachable(err error) bool {
+	var urlErr *url.Error
+	var netErr net.Error
+	return errors.As(err, &urlErr) || errors.As(err, &netErr)
+}
+
+// Gets the chain height from the best node
+// return - A [BlockHeight] struct
+func (p *Pool) Height() (BlockHeight, error) {
+	return p.HeightCtx(context.Background())
+}
+
+// Same as Height, the request is bound to ctx
+func (p *Pool) HeightCtx(ctx context.Context) (BlockHeight, error) {
+	var data BlockHeight
+	err := p.Do(ctx, func(c *Client) (err error) {
+		data, err = c.HeightCtx(ctx)
+		return err
+	})
+	return data, err
+}
+
+// Announce a transaction to the best nodes, Broadcast nodes at once
+// The transaction is accepted when one node accepts it.
+// param serialize - A RequestAnnounce struct
+// return - A [NemAnnounceResult] struct
+// link http://bob.nem.ninja/docs/#nemAnnounceResult
+func (p *Pool) Announce(serialize RequestAnnounce) (*NemAnnounceResult, error) {
+	return p.AnnounceCtx(context.Background(), serialize)
+}
+
+// Same as Announce, the request is bound to ctx
+func (p *Pool) AnnounceCtx(ctx context.Context, serialize RequestAnnounce) (*NemAnnounceResult, error) {
+	if p.Broadcast <= 1 {
+		var data *NemAnnounceResult
+		err := p.Do(ctx, func(c *Client) (err error) {
+			data, err = c.AnnounceCtx(ctx, serialize)
+			return err
+		})
+		return data, err
+	}
+
+	ranked := p.ranked()
+	if len(ranked) == 0 {
+		return nil, ErrNoNode
+	}
+	if len(ranked) > p.Broadcast {
+		ranked = ranked[:p.Broadcast]
+	}
+
+	results := make([]*NemAnnounceResult, len(ranked))
+	errs := make([]error, len(ranked))
+	var wg sync.WaitGroup
+	for n, i := range ranked {
+		wg.Add(1)
+		go func(n, i int) {
+			defer wg.Done()
+			results[n], errs[n] = p.clients[i].AnnounceCtx(ctx, serialize)
+			if errs[n] != nil && ctx.Err() == nil && unreachable(errs[n]) {
+				p.fail(i, errs[n])
+			}
+		}(n, i)
+	}
+	wg.Wait()
+
+	// A success first, then the answer of the best node, then the first error
+	for n, r := range results {
+		if r != nil && errs[n] == nil {
+			return r, nil
+		}
+	}
+	for n, r := range results {
+		if r != nil {
+			return r, errs[n]
+		}
+	}
+	return nil, errs[0]
+}
@@ -0,0 +1,309 @@
+package requests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/isarq/nem-sdk-go/base"
+)
+
+// A NIS stand-in for the pool, answering the heartbeat, height and announce requests
+type poolNode struct {
+	srv    *httptest.Server
+	height int64
+	// delay slows down the heartbeat
+	delay time.Duration
+	// heartbeat is the code of the heartbeat, 1 when zero
+	heartbeat int
+	// announce is the answer to an announce, accepted when nil
+	announce *NemAnnounceResult
+	// status is the HTTP status of every request, 200 when zero
+	status int
+
+	heartbeats int32
+	heights    int32
+	announces  int32
+}
+
+func newPoolNode(t *testing.T, height int64) *poolNode {
+	n := &poolNode{height: height}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/heartbeat", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&n.heartbeats, 1)
+		time.Sleep(n.delay)
+		code := n.heartbeat
+		if code == 0 {
+			code = 1
+		}
+		json.NewEncoder(w).Encode(NemRequestResult{Type: 2, Code: code, Message: "ok"})
+	})
+	mux.HandleFunc("/chain/height", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&n.heights, 1)
+		if n.status != 0 {
+			http.Error(w, `{"status":400,"error":"Bad Request","message":"FAILURE_UNKNOWN"}`, n.status)
+			return
+		}
+		json.NewEncoder(w).Encode(BlockHeight{Height: n.height})
+	})
+	mux.HandleFunc("/transaction/announce", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&n.announces, 1)
+		result := NemAnnounceResult{Type: 1, Code: 1, Message: "SUCCESS", TransactionHash: TransactionHash{Data: strconv.FormatInt(n.height, 10)}}
+		if n.announce != nil {
+			result = *n.announce
+		}
+		json.NewEncoder(w).Encode(result)
+	})
+	n.srv = httptest.NewServer(mux)
+	t.Cleanup(n.srv.Close)
+	return n
+}
+
+// A node which can not be reached
+func newDownNode(t *testing.T) *poolNode {
+	n := newPoolNode(t, 0)
+	n.srv.Close()
+	return n
+}
+
+func (n *poolNode) node(t *testing.T) base.Node {
+	u, err := url.Parse(n.srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base.Node{Host: "http://" + u.Hostname(), Port: port}
+}
+
+func newTestPool(t *testing.T, nodes ...*poolNode) *Pool {
+	endpoints := make([]base.Node, len(nodes))
+	for i, n := range nodes {
+		endpoints[i] = n.node(t)
+	}
+	return NewPool(endpoints)
+}
+
+func TestNewPool(t *testing.T) {
+	a, b := newPoolNode(t, 1), newPoolNode(t, 2)
+	p := newTestPool(t, a, b)
+	if p.MaxHeightLag != DefaultMaxHeightLag {
+		t.Errorf("MaxHeightLag = %d, want %d", p.MaxHeightLag, DefaultMaxHeightLag)
+	}
+	status := p.Status()
+	if len(status) != 2 || status[0].Node != a.node(t) || status[1].Node != b.node(t) {
+		t.Fatalf("Status = %+v", status)
+	}
+	for i, s := range status {
+		if !s.Healthy || !s.CheckedAt.IsZero() {
+			t.Errorf("node %d: %+v, want healthy and never checked", i, s)
+		}
+	}
+
+	if _, err := NewPool(nil).Client(); err != ErrNoNode {
+		t.Errorf("Client of an empty pool: err = %v, want ErrNoNode", err)
+	}
+	if _, err := NewPool(nil).Height(); err != ErrNoNode {
+		t.Errorf("Height of an empty pool: err = %v, want ErrNoNode", err)
+	}
+}
+
+func TestPoolCheck(t *testing.T) {
+	synced, behind, down, failing := newPoolNode(t, 100), newPoolNode(t, 90), newDownNode(t), newPoolNode(t, 100)
+	failing.heartbeat = 2
+	p := newTestPool(t, synced, behind, down, failing)
+
+	status := p.Check(context.Background())
+	for i, want := range []struct {
+		healthy bool
+		height  int64
+	}{{true, 100}, {true, 90}, {false, 0}, {false, 0}} {
+		s := status[i]
+		if s.Healthy != want.healthy || s.Height != want.height || s.CheckedAt.IsZero() {
+			t.Errorf("node %d: %+v, want healthy %v at %d", i, s, want.healthy, want.height)
+		}
+		if s.Healthy != (s.Err == nil) {
+			t.Errorf("node %d: healthy %v with err %v", i, s.Healthy, s.Err)
+		}
+	}
+	if failing.heights != 0 {
+		t.Error("the height of a failing heartbeat is requested")
+	}
+
+	// The node behind is only used after the node in sync
+	c, err := p.Client()
+	if err != nil || c.Node != synced.node(t) {
+		t.Errorf("Client = %v, %v, want the node in sync", c, err)
+	}
+	synced.status = http.StatusInternalServerError
+	h, err := p.Height()
+	if err != nil || h.Height != 90 {
+		t.Errorf("Height = %d, %v, want 90 from the node behind", h.Height, err)
+	}
+}
+
+func TestPoolRanking(t *testing.T) {
+	slow, fast, behind := newPoolNode(t, 100), newPoolNode(t, 99), newPoolNode(t, 90)
+	slow.delay = 50 * time.Millisecond
+	p := newTestPool(t, behind, slow, fast)
+	p.Check(context.Background())
+
+	// The fastest node in sync first, then by latency, then the nodes behind by height
+	want := []base.Node{fast.node(t), slow.node(t), behind.node(t)}
+	for n, i := range p.ranked() {
+		if p.clients[i].Node != want[n] {
+			t.Errorf("rank %d: %v, want %v", n, p.clients[i].Node, want[n])
+		}
+	}
+	h, err := p.HeightCtx(context.Background())
+	if err != nil || h.Height != 99 {
+		t.Errorf("Height = %d, %v, want 99 from the fastest node", h.Height, err)
+	}
+
+	// The highest node becomes the only one in sync
+	p.MaxHeightLag = 0
+	c, err := p.Client()
+	if err != nil || c.Node != slow.node(t) {
+		t.Errorf("Client = %v, %v, want the highest node", c, err)
+	}
+}
+
+func TestPoolFailover(t *testing.T) {
+	down, up := newDownNode(t), newPoolNode(t, 7)
+	p := newTestPool(t, down, up)
+
+	h, err := p.Height()
+	if err != nil || h.Height != 7 {
+		t.Fatalf("Height = %d, %v, want 7", h.Height, err)
+	}
+	status := p.Status()
+	if status[0].Healthy || status[0].Err == nil || !status[1].Healthy {
+		t.Errorf("Status = %+v, want the node down unhealthy", status)
+	}
+	if _, err := p.Height(); err != nil || up.heights != 2 {
+		t.Errorf("second Height: %v, %d requests, want 2", err, up.heights)
+	}
+
+	// Without a healthy node the unhealthy ones are tried
+	p = newTestPool(t, down)
+	if _, err := p.Height(); err == nil {
+		t.Fatal("no error from a node down")
+	}
+	var urlErr *url.Error
+	if _, err := p.Height(); !errors.As(err, &urlErr) {
+		t.Errorf("err = %v, want the error of the node down", err)
+	}
+
+	// A request rejected by NIS is not tried on the next node
+	rejecting, next := newPoolNode(t, 7), newPoolNode(t, 7)
+	rejecting.status = http.StatusBadRequest
+	p = newTestPool(t, rejecting, next)
+	var nisErr *NisError
+	if _, err := p.Height(); !errors.As(err, &nisErr) || next.heights != 0 {
+		t.Errorf("err = %v with %d requests to the next node, want the NIS error only", err, next.heights)
+	}
+	if !p.Status()[0].Healthy {
+		t.Error("a node answering an error is marked unhealthy")
+	}
+
+	// A node failing with a server error is not marked unhealthy but the next node is tried
+	rejecting.status = http.StatusInternalServerError
+	if h, err := p.Height(); err != nil || h.Height != 7 || next.heights != 1 {
+		t.Errorf("Height = %d, %v with %d requests to the next node", h.Height, err, next.heights)
+	}
+
+	// The fail over stops when ctx is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p = newTestPool(t, down, up)
+	if _, err := p.HeightCtx(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestPoolRun(t *testing.T) {
+	n := newPoolNode(t, 3)
+	p := newTestPool(t, n)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		p.Run(ctx, 10*time.Millisecond)
+		close(done)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&n.heartbeats) < 3 {
+		if time.Now().After(deadline) {
+			t.Fatal("the nodes are not checked every interval")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run does not return when ctx is done")
+	}
+	if s := p.Status()[0]; !s.Healthy || s.Height != 3 || s.CheckedAt.IsZero() {
+		t.Errorf("Status = %+v", s)
+	}
+}
+
+func TestPoolBroadcast(t *testing.T) {
+	announce := RequestAnnounce{Data: "00", Signature: "00"}
+	rejected := &NemAnnounceResult{Type: 1, Code: 5, Message: "FAILURE_INSUFFICIENT_BALANCE"}
+
+	down, rejecting, accepting, last := newDownNode(t), newPoolNode(t, 1), newPoolNode(t, 2), newPoolNode(t, 3)
+	rejecting.announce = rejected
+	p := newTestPool(t, down, rejecting, accepting, last)
+	p.Broadcast = 3
+
+	// Announced to the first 3 nodes, one success is enough
+	result, err := p.Announce(announce)
+	if err != nil || result.Code != 1 || result.TransactionHash.Data != "2" {
+		t.Fatalf("Announce = %+v, %v, want the success of the accepting node", result, err)
+	}
+	if rejecting.announces != 1 || accepting.announces != 1 || last.announces != 0 {
+		t.Errorf("announces = %d, %d, %d, want 1, 1, 0", rejecting.announces, accepting.announces, last.announces)
+	}
+	if p.Status()[0].Healthy {
+		t.Error("the node down is still healthy")
+	}
+
+	// The node down is not used anymore, every node rejects the transaction
+	accepting.announce, last.announce = rejected, rejected
+	result, err = p.AnnounceCtx(context.Background(), announce)
+	if !errors.Is(err, ErrInsufficientBalance) || result == nil || result.Code != 5 {
+		t.Errorf("Announce = %+v, %v, want the rejection", result, err)
+	}
+	if last.announces != 1 {
+		t.Errorf("%d announces to the last node, want 1", last.announces)
+	}
+
+	// Every node down
+	p = newTestPool(t, down, newDownNode(t))
+	p.Broadcast = 2
+	if result, err := p.Announce(announce); err == nil || result != nil {
+		t.Errorf("Announce = %+v, %v, want an error", result, err)
+	}
+
+	// Without broadcast the announce fails over to the next node only when unreachable
+	p = newTestPool(t, down, accepting, last)
+	accepting.announce = nil
+	if result, err := p.Announce(announce); err != nil || result.TransactionHash.Data != "2" {
+		t.Errorf("Announce = %+v, %v, want the success of the accepting node", result, err)
+	}
+	accepting.announce = rejected
+	if _, err := p.Announce(announce); !errors.Is(err, ErrInsufficientBalance) || last.announces != 1 {
+		t.Errorf("err = %v with %d announces to the last node, want the rejection only", err, last.announces)
+	}
+}
@@ -2,21 +2,58 @@ package requests
 
 import (
 	"bytes"
+	"context"
+	"io/ioutil"
 	"net/http"
 )
 
-func (c Client) buildReq(params map[string]string, body []byte, method string) (*http.Request, error) {
+// Build a request to a path of the node
+// The base URL of the client is copied, never modified, so requests can be built concurrently.
+func (c *Client) buildReq(path string, params map[string]string, body []byte, method string) (*http.Request, error) {
+	u := c.URL
+	u.Path = path
 	if params != nil {
-		q := c.URL.Query()
+		q := u.Query()
 		for k, v := range params {
 			q.Set(k, v)
 		}
-		c.URL.RawQuery = q.Encode()
+		u.RawQuery = q.Encode()
 	}
 
-	req, err := http.NewRequest(method, c.URL.String(), bytes.NewBuffer(body))
+	req, err := http.NewRequest(method, u.String(), bytes.NewBuffer(body))
 	if err != nil {
 		return &http.Request{}, err
 	}
 	return req, nil
 }
+
+// Send a request bound to ctx and read the body of the response
+// A response other than 200 is returned as a *NisError.
+func (c *Client) do(ctx context.Context, req *http.Request) ([]byte, error) {
+	req = req.WithContext(ctx)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	if c.Request != nil {
+		return c.Request(req)
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = defaultHTTPClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	byteArray, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, newNisError(resp.StatusCode, byteArray)
+	}
+	return byteArray, nil
+}
@@ -1,10 +1,10 @@
 package requests
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/isarq/nem-sdk-go/model"
-	"io/ioutil"
 	"net/http"
 	"strings"
 	"time"
@@ -53,32 +53,25 @@ type Coords struct {
 // Gets all nodes of the node reward program
 // return - An SuperNodeInfo struct
 func SuperNodeAll() (SuperNodeInfo, error) {
-	c := Client{}
-	timeout := time.Duration(50 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
+	return SuperNodeAllCtx(context.Background())
+}
+
+// Same as SuperNodeAll, the request is bound to ctx
+func SuperNodeAllCtx(ctx context.Context) (SuperNodeInfo, error) {
+	c := Client{HTTPClient: &http.Client{Timeout: 50 * time.Second}}
 	node := strings.Split(model.Supernodes, "//")
 	node = strings.Split(node[1], "/")
 	c.URL.Host = node[0]
-	c.URL.Path = "/nodes"
 	c.URL.Scheme = "https"
-	req, err := c.buildReq(nil, nil, http.MethodGet)
+	req, err := c.buildReq("/nodes", nil, nil, http.MethodGet)
 	if err != nil {
 		return SuperNodeInfo{}, err
 	}
 
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return SuperNodeInfo{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return SuperNodeInfo{}, err
-	}
 
 	var data = SuperNodeInfo{}
 	if err := json.Unmarshal(byteArray, &data); err != nil {
@@ -91,11 +84,12 @@ func SuperNodeAll() (SuperNodeInfo, error) {
 // param coords - A coordinates object: https://www.w3schools.com/html/html5_geolocation.asp
 // return - An SuperNodeInfo struct
 func Nearest(latitude, longitude float64) ([]SuperNode, error) {
-	c := Client{}
-	timeout := time.Duration(20 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
+	return NearestCtx(context.Background(), latitude, longitude)
+}
+
+// Same as Nearest, the request is bound to ctx
+func NearestCtx(ctx context.Context, latitude, longitude float64) ([]SuperNode, error) {
+	c := Client{HTTPClient: &http.Client{Timeout: 20 * time.Second}}
 
 	Ojt := Coords{Latitude: latitude, Longitude: longitude, NumNodes: 5}
 
@@ -104,25 +98,17 @@ func Nearest(latitude, longitude float64) ([]SuperNode, error) {
 		return []SuperNode{}, err
 	}
 	c.URL.Host = "199.217.113.179:7782"
-	c.URL.Path = "/nodes/nearest"
 	c.URL.Scheme = "http"
-	req, err := c.buildReq(nil, payload, http.MethodPost)
+	req, err := c.buildReq("/nodes/nearest", nil, payload, http.MethodPost)
 	if err != nil {
 		return []SuperNode{}, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return []SuperNode{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []SuperNode{}, err
-	}
 	// The data is returned as a nested json array
 	// This enables us to not return the array nested
 	// as a value under a "data" key
@@ -137,11 +123,12 @@ func Nearest(latitude, longitude float64) ([]SuperNode, error) {
 // param {number} status - 0 for all nodes, 1 for active nodes, 2 for inactive nodes
 // return {struct} - An SuperNodeInfo struct
 func GetSuperNodeStatus(status int) ([]SuperNode, error) {
-	c := Client{}
-	timeout := time.Duration(60 * time.Second)
-	client := http.Client{
-		Timeout: timeout,
-	}
+	return GetSuperNodeStatusCtx(context.Background(), status)
+}
+
+// Same as GetSuperNodeStatus, the request is bound to ctx
+func GetSuperNodeStatusCtx(ctx context.Context, status int) ([]SuperNode, error) {
+	c := Client{HTTPClient: &http.Client{Timeout: 60 * time.Second}}
 	if status > 2 {
 		err := errors.New("error: status - 0 for all nodes, 1 for active nodes, 2 for inactive nodes")
 		return []SuperNode{}, err
@@ -152,25 +139,17 @@ func GetSuperNodeStatus(status int) ([]SuperNode, error) {
 		return []SuperNode{}, err
 	}
 	c.URL.Host = "199.217.113.179:7782"
-	c.URL.Path = "/nodes"
 	c.URL.Scheme = "http"
-	req, err := c.buildReq(nil, payload, http.MethodPost)
+	req, err := c.buildReq("/nodes", nil, payload, http.MethodPost)
 	if err != nil {
 		return []SuperNode{}, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return []SuperNode{}, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-
-	if resp.StatusCode != 200 {
-		err := errors.New(string(byteArray))
-		return []SuperNode{}, err
-	}
 	// The data is returned as a nested json array
 	// This enables us to not return the array nested
 	// as a value under a "data" key
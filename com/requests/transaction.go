@@ -2,13 +2,10 @@ package requests
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"net/http"
-	"time"
 )
 
 // The NemAnnounceResult extends the NemRequestResult by supplying
@@ -39,20 +36,19 @@ type RequestAnnounce struct {
 // Broadcast a transaction to the NEM network
 // Method Client - An Client endpoint struct point
 // param serialize - A RequestAnnounce struct
-// return - A [NemAnnounceResult] struct
+// return - A [NemAnnounceResult] struct, with an *AnnounceError when NIS rejects the transaction
 // link http://bob.nem.ninja/docs/#nemAnnounceResult
 func (c *Client) Announce(serialize RequestAnnounce) (*NemAnnounceResult, error) {
-	timeout := 10 * time.Second
-	client := http.Client{
-		Timeout: timeout,
-	}
+	return c.AnnounceCtx(context.Background(), serialize)
+}
 
+// Same as Announce, the request is bound to ctx
+func (c *Client) AnnounceCtx(ctx context.Context, serialize RequestAnnounce) (*NemAnnounceResult, error) {
 	payload, err := json.Marshal(serialize)
 	if err != nil {
 		return nil, err
 	}
-	c.URL.Path = "/transaction/announce"
-	req, err := c.buildReq(nil, payload, http.MethodPost)
+	req, err := c.buildReq("/transaction/announce", nil, payload, http.MethodPost)
 	if err != nil {
 		return nil, err
 	}
@@ -60,19 +56,10 @@ func (c *Client) Announce(serialize RequestAnnounce) (*NemAnnounceResult, error)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Content-Length", fmt.Sprintf("%v", len(payload)))
 
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	byteArray, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode != 200 {
-		return nil, errors.New(string(byteArray))
-	}
 	// The data is returned as a nested json array
 	// This enables us to not return the array nested
 	// as a value under a "data" key
@@ -80,6 +67,9 @@ func (c *Client) Announce(serialize RequestAnnounce) (*NemAnnounceResult, error)
 	if err = json.Unmarshal(byteArray, &data); err != nil {
 		return nil, err
 	}
+	if data.Code != 1 {
+		return &data, &AnnounceError{Result: data}
+	}
 	return &data, nil
 }
 
@@ -89,31 +79,21 @@ func (c *Client) Announce(serialize RequestAnnounce) (*NemAnnounceResult, error)
 // return A [TransactionMetaDataPair] struct
 // link http://bob.nem.ninja/docs/#transactionMetaDataPair
 func (c *Client) ByHash(txHash string) (*TransactionMetaDataPair, error) {
-	b := new(bytes.Buffer)
-	timeout := 10 * time.Second
-	client := http.Client{
-		Timeout: timeout,
-	}
-	c.URL.Path = "/transaction/get"
-	req, err := c.buildReq(map[string]string{"hash": txHash}, nil, http.MethodGet)
+	return c.ByHashCtx(context.Background(), txHash)
+}
+
+// Same as ByHash, the request is bound to ctx
+func (c *Client) ByHashCtx(ctx context.Context, txHash string) (*TransactionMetaDataPair, error) {
+	req, err := c.buildReq("/transaction/get", map[string]string{"hash": txHash}, nil, http.MethodGet)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := client.Do(req)
+	byteArray, err := c.do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		b := &bytes.Buffer{}
-		_, _ = b.ReadFrom(resp.Body)
-		return nil, errors.New(b.String())
-	}
-
-	_, _ = io.Copy(b, resp.Body)
 
-	meta, tx, err := MapTransaction(b)
+	meta, tx, err := MapTransaction(bytes.NewBuffer(byteArray))
 	if err != nil {
 		return nil, err
 	}
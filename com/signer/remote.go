@@ -0,0 +1,225 @@
+// Package signer signs transactions in a separate signing daemon over HTTP/JSON,
+// so the private keys never reach the process building and announcing the transactions.
+//
+// A Remote is a transactions.Signer talking to the daemon, Server is a reference
+// daemon serving any transactions.Signer, e.g. a keystore account:
+//
+//	ks, _ := keystore.Open(dir)
+//	keys, _ := transactions.NewKeystoreSigner(ks, address, password)
+//	srv, _ := signer.NewServer(keys)
+//	http.ListenAndServe("127.0.0.1:7891", srv)
+//
+// and on the other side:
+//
+//	remote, err := signer.Dial(ctx, "http://127.0.0.1:7891", address)
+//	tx, err := transfer.Prepare(remote, model.Data.Testnet.ID)
+//	result, err := transactions.Send(remote, tx, client)
+//
+// The protocol has two endpoints:
+//
+//	GET  /accounts  -> {"data": [{"address": "", "publicKey": "", "network": -104}]}
+//	POST /sign      {"publicKey": "", "data": "<hex payload>"} -> {"signature": "<hex>"}
+//
+// An error is answered with a status other than 200 and {"error": "message"}.
+package signer // import "github.com/isarq/nem-sdk-go/com/signer"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/model/transactions"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+// The timeout of the requests when no HTTP client is given
+const DefaultTimeout = 10 * time.Second
+
+var defaultHTTPClient = &http.Client{Timeout: DefaultTimeout}
+
+var ErrUnknownAccount = errors.New("signer: unknown account")
+
+// An Account served by a signing daemon
+type Account struct {
+	Address   string `json:"address"`
+	PublicKey string `json:"publicKey"`
+	Network   int    `json:"network"`
+}
+
+type signRequest struct {
+	PublicKey string `json:"publicKey"`
+	Data      string `json:"data"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// A RemoteError is an error answered by a signing daemon
+type RemoteError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *RemoteError) Error() string {
+	return "signer: " + e.Message
+}
+
+// A Remote signs with an account of a signing daemon, it is a transactions.Signer
+type Remote struct {
+	// URL is the base URL of the daemon
+	URL string
+	// Token is sent as a bearer token when set
+	Token string
+	// HTTPClient sends the requests, a client with DefaultTimeout when nil
+	HTTPClient *http.Client
+
+	account Account
+}
+
+var _ transactions.Signer = (*Remote)(nil)
+
+// A RemoteOption configures a Remote created with Dial
+type RemoteOption func(*Remote)
+
+// Send the bearer token with the requests
+// param token - A token of the daemon
+func WithToken(token string) RemoteOption {
+	return func(r *Remote) {
+		r.Token = token
+	}
+}
+
+// Send the requests with the given HTTP client
+// param client - An http.Client point
+func WithHTTPClient(client *http.Client) RemoteOption {
+	return func(r *Remote) {
+		r.HTTPClient = client
+	}
+}
+
+// Connect to an account of a signing daemon
+// param ctx - Bounds the request of the account
+// param url - The base URL of the daemon, e.g. http://127.0.0.1:7891
+// param account - The address or the public key of the account
+// param options - Options of the Remote
+// return - A Remote point
+func Dial(ctx context.Context, url, account string, options ...RemoteOption) (*Remote, error) {
+	if url == "" || account == "" {
+		return nil, errors.New("missing parameter !")
+	}
+	r := &Remote{URL: strings.TrimRight(url, "/")}
+	for _, option := range options {
+		option(r)
+	}
+	accounts, err := r.AccountsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id := strings.ToUpper(strings.Replace(account, "-", "", -1))
+	for _, a := range accounts {
+		if strings.ToUpper(a.Address) == id || strings.EqualFold(a.PublicKey, account) {
+			r.account = a
+			return r, nil
+		}
+	}
+	return nil, ErrUnknownAccount
+}
+
+// Gets the accounts of the daemon
+// return - A slice of Account struct
+func (r *Remote) Accounts() ([]Account, error) {
+	return r.AccountsCtx(context.Background())
+}
+
+// Same as Accounts, the request is bound to ctx
+func (r *Remote) AccountsCtx(ctx context.Context) ([]Account, error) {
+	var data struct{ Data []Account }
+	if err := r.do(ctx, http.MethodGet, "/accounts", nil, &data); err != nil {
+		return nil, err
+	}
+	return data.Data, nil
+}
+
+// Gets the address of the account
+func (r *Remote) Address() base.Address {
+	return base.Address(r.account.Address)
+}
+
+func (r *Remote) PublicKey() string {
+	return r.account.PublicKey
+}
+
+func (r *Remote) Network() int {
+	return r.account.Network
+}
+
+func (r *Remote) Sign(payload []byte) ([]byte, error) {
+	return r.SignCtx(context.Background(), payload)
+}
+
+// Same as Sign, the request is bound to ctx
+func (r *Remote) SignCtx(ctx context.Context, payload []byte) ([]byte, error) {
+	req := signRequest{PublicKey: r.account.PublicKey, Data: utils.Bt2Hex(payload)}
+	var resp signResponse
+	if err := r.do(ctx, http.MethodPost, "/sign", req, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Signature) != 128 || !utils.IsHexadecimal(resp.Signature) {
+		return nil, errors.New("signer: invalid signature")
+	}
+	return utils.Hex2Bt(resp.Signature), nil
+}
+
+func (r *Remote) do(ctx context.Context, method, path string, body, v interface{}) error {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, r.URL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.Token)
+	}
+
+	client := r.HTTPClient
+	if client == nil {
+		client = defaultHTTPClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e errorResponse
+		if json.Unmarshal(data, &e) != nil || e.Error == "" {
+			e.Error = http.StatusText(resp.StatusCode)
+		}
+		return &RemoteError{StatusCode: resp.StatusCode, Message: e.Error}
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,155 @@
+package signer
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/model/transactions"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+// The largest request body accepted by a Server
+const maxRequestBytes = 1 << 20
+
+// A Server is a reference signing daemon serving the accounts of its Signers.
+// It is an http.Handler, safe for concurrent use; the fields must be set before use.
+type Server struct {
+	// Token, when set, must be sent as a bearer token by the clients
+	Token string
+	// Approve, when set, is called before every signature, an error refuses to sign
+	Approve func(account Account, payload []byte) error
+
+	mu       sync.RWMutex
+	accounts []Account
+	signers  map[string]transactions.Signer
+}
+
+// Create a signing daemon
+// param signers - The Signers of the served accounts
+// return - A Server point, an error when a Signer is not valid
+func NewServer(signers ...transactions.Signer) (*Server, error) {
+	s := &Server{signers: make(map[string]transactions.Signer)}
+	for _, signer := range signers {
+		if err := s.Add(signer); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Serve the account of a Signer
+// param signer - A Signer bound to a network
+// return - An error when the Signer has no valid public key or network
+func (s *Server) Add(signer transactions.Signer) error {
+	if signer.Network() == 0 {
+		return errors.New("the signer must be bound to a network !")
+	}
+	publicKey := strings.ToLower(signer.PublicKey())
+	if !utils.IsPublicKeyValid(publicKey) {
+		return errors.New("invalid signer public key !")
+	}
+	address, err := model.ToAddress(publicKey, signer.Network())
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.signers[publicKey]; !ok {
+		s.accounts = append(s.accounts, Account{Address: address, PublicKey: publicKey, Network: signer.Network()})
+	}
+	s.signers[publicKey] = signer
+	return nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
+	switch r.URL.Path {
+	case "/accounts":
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		s.mu.RLock()
+		accounts := append([]Account{}, s.accounts...)
+		s.mu.RUnlock()
+		writeJSON(w, struct {
+			Data []Account `json:"data"`
+		}{accounts})
+	case "/sign":
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		s.sign(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.Token == "" {
+		return true
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) == 1
+}
+
+func (s *Server) sign(w http.ResponseWriter, r *http.Request) {
+	var req signRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+	if req.Data == "" || len(req.Data)%2 != 0 || !utils.IsHexadecimal(req.Data) {
+		writeError(w, http.StatusBadRequest, "data must be hexadecimal")
+		return
+	}
+
+	publicKey := strings.ToLower(req.PublicKey)
+	s.mu.RLock()
+	signer, ok := s.signers[publicKey]
+	var account Account
+	for _, a := range s.accounts {
+		if a.PublicKey == publicKey {
+			account = a
+		}
+	}
+	s.mu.RUnlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, ErrUnknownAccount.Error())
+		return
+	}
+
+	payload := utils.Hex2Bt(req.Data)
+	if s.Approve != nil {
+		if err := s.Approve(account, payload); err != nil {
+			writeError(w, http.StatusForbidden, err.Error())
+			return
+		}
+	}
+	signature, err := signer.Sign(payload)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, signResponse{Signature: utils.Bt2Hex(signature)})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Error: message})
+}
@@ -0,0 +1,72 @@
+package signer_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/com/requests/nistest"
+	"github.com/isarq/nem-sdk-go/com/signer"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/model/transactions"
+)
+
+const privateKey = "0e0f2b5d5d2a1e9ba3d4dd8f1bb6e0be5b7c8f1c29e1b9b3a55f23d44a8fa4d1"
+
+func TestRemoteSigner(t *testing.T) {
+	keys, err := transactions.NewMemorySigner(privateKey, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	daemon, err := signer.NewServer(keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	daemon.Token = "secret"
+	srv := httptest.NewServer(daemon)
+	defer srv.Close()
+
+	ctx := context.Background()
+	if _, err := signer.Dial(ctx, srv.URL, keys.PublicKey()); err == nil {
+		t.Error("Dial without the token succeeded")
+	}
+	remote, err := signer.Dial(ctx, srv.URL, keys.PublicKey(), signer.WithToken("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remote.Network() != model.Data.Testnet.ID {
+		t.Errorf("Network() = %d, want %d", remote.Network(), model.Data.Testnet.ID)
+	}
+
+	nis := nistest.NewServer()
+	defer nis.Close()
+	nis.SetAccount(requests.AccountInfo{Address: remote.Address().String(), Balance: 10 * base.XEM})
+
+	tx := transactions.Transfer{Amount: base.XEM, Recipient: remote.Address()}
+	prepared, err := tx.Prepare(remote, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := transactions.Send(remote, prepared, nis.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Code != 1 {
+		t.Errorf("announce result = %+v", result)
+	}
+
+	// An encrypted message needs the private key
+	tx.MessageType, tx.Message, tx.RecipientPublicKey = 2, "hello", keys.PublicKey()
+	if _, err := tx.Prepare(remote, model.Data.Testnet.ID); err == nil {
+		t.Error("Prepare an encrypted message with a remote signer succeeded")
+	}
+
+	daemon.Approve = func(signer.Account, []byte) error { return errors.New("refused") }
+	var remoteErr *signer.RemoteError
+	if _, err := remote.Sign([]byte{1}); !errors.As(err, &remoteErr) || remoteErr.Message != "refused" {
+		t.Errorf("Sign refused by the daemon: err = %v", err)
+	}
+}
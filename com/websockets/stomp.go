@@ -0,0 +1,147 @@
+package websockets
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// STOMP commands used with NIS
+const (
+	stompConnect     = "CONNECT"
+	stompConnected   = "CONNECTED"
+	stompSubscribe   = "SUBSCRIBE"
+	stompUnsubscribe = "UNSUBSCRIBE"
+	stompSend        = "SEND"
+	stompMessage     = "MESSAGE"
+	stompError       = "ERROR"
+)
+
+var errBadFrame = errors.New("malformed STOMP frame")
+
+// A STOMP frame
+type frame struct {
+	command string
+	headers map[string]string
+	body    []byte
+}
+
+// Encode a frame: the command, the headers and the body terminated by a NULL byte
+func (f frame) encode() []byte {
+	var b bytes.Buffer
+	b.WriteString(f.command)
+	b.WriteByte('\n')
+
+	keys := make([]string, 0, len(f.headers))
+	for k := range f.headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	escape := f.escaped()
+	for _, k := range keys {
+		b.WriteString(escape(k))
+		b.WriteByte(':')
+		b.WriteString(escape(f.headers[k]))
+		b.WriteByte('\n')
+	}
+	if len(f.body) > 0 {
+		b.WriteString("content-length:")
+		b.WriteString(strconv.Itoa(len(f.body)))
+		b.WriteByte('\n')
+	}
+	b.WriteByte('\n')
+	b.Write(f.body)
+	b.WriteByte(0)
+	return b.Bytes()
+}
+
+// Decode the frames of a websocket message, heart-beats are skipped
+func decodeFrames(data []byte) ([]frame, error) {
+	var frames []frame
+	for {
+		data = bytes.TrimLeft(data, "\r\n")
+		if len(data) == 0 {
+			return frames, nil
+		}
+		f, rest, err := decodeFrame(data)
+		if err != nil {
+			return frames, err
+		}
+		frames = append(frames, f)
+		data = rest
+	}
+}
+
+func decodeFrame(data []byte) (frame, []byte, error) {
+	end := bytes.Index(data, []byte("\n\n"))
+	sep := 2
+	if crlf := bytes.Index(data, []byte("\r\n\r\n")); crlf >= 0 && (end < 0 || crlf < end) {
+		end, sep = crlf, 4
+	}
+	if end < 0 {
+		return frame{}, nil, errBadFrame
+	}
+
+	lines := strings.Split(strings.Replace(string(data[:end]), "\r\n", "\n", -1), "\n")
+	f := frame{command: lines[0], headers: make(map[string]string)}
+	unescape := f.unescaped()
+	for _, line := range lines[1:] {
+		i := strings.IndexByte(line, ':')
+		if i < 0 {
+			return frame{}, nil, errBadFrame
+		}
+		// The first occurrence of a repeated header is the one used
+		k := unescape(line[:i])
+		if _, ok := f.headers[k]; !ok {
+			f.headers[k] = unescape(line[i+1:])
+		}
+	}
+
+	body := data[end+sep:]
+	if l, ok := f.headers["content-length"]; ok {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 0 || n >= len(body) || body[n] != 0 {
+			return frame{}, nil, errBadFrame
+		}
+		f.body = body[:n]
+		return f, body[n+1:], nil
+	}
+	n := bytes.IndexByte(body, 0)
+	if n < 0 {
+		return frame{}, nil, errBadFrame
+	}
+	f.body = body[:n]
+	return f, body[n+1:], nil
+}
+
+var headerEscaper = strings.NewReplacer("\\", "\\\\", "\r", "\\r", "\n", "\\n", ":", "\\c")
+var headerUnescaper = strings.NewReplacer("\\\\", "\\", "\\r", "\r", "\\n", "\n", "\\c", ":")
+
+// The headers of the CONNECT and CONNECTED frames are not escaped (STOMP 1.2)
+func (f frame) escaped() func(string) string {
+	if f.command == stompConnect || f.command == stompConnected {
+		return verbatim
+	}
+	return escapeHeader
+}
+
+func (f frame) unescaped() func(string) string {
+	if f.command == stompConnect || f.command == stompConnected {
+		return verbatim
+	}
+	return unescapeHeader
+}
+
+func verbatim(s string) string {
+	return s
+}
+
+func escapeHeader(s string) string {
+	return headerEscaper.Replace(s)
+}
+
+func unescapeHeader(s string) string {
+	return headerUnescaper.Replace(s)
+}
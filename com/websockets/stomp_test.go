@@ -0,0 +1,74 @@
+package websockets
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFrameRoundTrip(t *testing.T) {
+	for _, f := range []frame{
+		{command: stompConnect, headers: map[string]string{"accept-version": "1.1,1.0", "heart-beat": "0,0", "host": "::1"}},
+		{command: stompConnected, headers: map[string]string{"version": "1.1", "server": "a:b\\c"}},
+		{command: stompSubscribe, headers: map[string]string{"id": "sub-1", "destination": "/account/TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S"}},
+		{command: stompSend, headers: map[string]string{"destination": "/w/api/account/get", "content-type": "application/json"},
+			body: []byte(`{"account":"TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S"}`)},
+		{command: stompMessage, headers: map[string]string{"subscription": "sub-1", "note": "a:b\\c\r\nd"}, body: []byte("with\x00null")},
+		{command: stompError, headers: map[string]string{"message": "bad"}},
+	} {
+		frames, err := decodeFrames(f.encode())
+		if err != nil {
+			t.Errorf("%s: %v", f.command, err)
+			continue
+		}
+		if len(frames) != 1 {
+			t.Errorf("%s: %d frames", f.command, len(frames))
+			continue
+		}
+		// The content-length header is added by encode
+		got := frames[0]
+		delete(got.headers, "content-length")
+		if got.command != f.command || !reflect.DeepEqual(got.headers, f.headers) || string(got.body) != string(f.body) {
+			t.Errorf("decode(encode(%+v)) = %+v", f, got)
+		}
+	}
+}
+
+func TestFrameEscaping(t *testing.T) {
+	f := frame{command: stompSubscribe, headers: map[string]string{"destination": "a:b\\c\nd"}}
+	if got, want := string(f.encode()), "SUBSCRIBE\ndestination:a\\cb\\\\c\\nd\n\n\x00"; got != want {
+		t.Errorf("encode = %q, want %q", got, want)
+	}
+	// The CONNECT and CONNECTED headers are not escaped
+	f = frame{command: stompConnect, headers: map[string]string{"host": "::1"}}
+	if got, want := string(f.encode()), "CONNECT\nhost:::1\n\n\x00"; got != want {
+		t.Errorf("encode = %q, want %q", got, want)
+	}
+	frames, err := decodeFrames([]byte("CONNECTED\nserver:a\\cb\n\n\x00"))
+	if err != nil || len(frames) != 1 || frames[0].headers["server"] != "a\\cb" {
+		t.Errorf("decode CONNECTED = %+v, %v", frames, err)
+	}
+}
+
+func TestDecodeFrames(t *testing.T) {
+	data := []byte("\n\nMESSAGE\r\nsubscription:sub-1\r\n\r\n{}\x00\nMESSAGE\nsubscription:sub-2\nsubscription:sub-3\n\n[]\x00\n")
+	frames, err := decodeFrames(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != 2 || frames[0].headers["subscription"] != "sub-1" || string(frames[0].body) != "{}" ||
+		frames[1].headers["subscription"] != "sub-2" || string(frames[1].body) != "[]" {
+		t.Errorf("decodeFrames = %+v", frames)
+	}
+
+	for name, data := range map[string]string{
+		"no headers end":       "MESSAGE\nsubscription:sub-1\n",
+		"no null byte":         "MESSAGE\n\n{}",
+		"header without colon": "MESSAGE\nsubscription\n\n{}\x00",
+		"long content-length":  "MESSAGE\ncontent-length:10\n\n{}\x00",
+		"bad content-length":   "MESSAGE\ncontent-length:x\n\n{}\x00",
+	} {
+		if _, err := decodeFrames([]byte(data)); err != errBadFrame {
+			t.Errorf("%s: err = %v, want errBadFrame", name, err)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package websockets
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+)
+
+// The NIS channels
+const (
+	newBlocksChannel   = "/blocks/new"
+	blocksChannel      = "/blocks"
+	accountChannel     = "/account/"
+	confirmedChannel   = "/transactions/"
+	unconfirmedChannel = "/unconfirmed/"
+	accountGetEndpoint = "/w/api/account/get"
+)
+
+// Subscribe to the height of every new block
+// return - A channel of [BlockHeight] struct and a function to unsubscribe, closing the channel
+func (c *Client) SubscribeHeight() (<-chan requests.BlockHeight, func(), error) {
+	ch := make(chan requests.BlockHeight)
+	s, err := c.subscribe(newBlocksChannel, func(body []byte, done <-chan struct{}) error {
+		var data requests.BlockHeight
+		if err := json.Unmarshal(body, &data); err != nil {
+			return err
+		}
+		select {
+		case ch <- data:
+		case <-done:
+		}
+		return nil
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, nil, err
+	}
+	return ch, func() { c.unsubscribe(s) }, nil
+}
+
+// Subscribe to the new blocks
+// return - A channel of [Block] struct and a function to unsubscribe, closing the channel
+// link http://bob.nem.ninja/docs/#block
+func (c *Client) SubscribeBlocks() (<-chan requests.Block, func(), error) {
+	ch := make(chan requests.Block)
+	s, err := c.subscribe(blocksChannel, func(body []byte, done <-chan struct{}) error {
+		var data requests.Block
+		if err := json.Unmarshal(body, &data); err != nil {
+			return err
+		}
+		select {
+		case ch <- data:
+		case <-done:
+		}
+		return nil
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, nil, err
+	}
+	return ch, func() { c.unsubscribe(s) }, nil
+}
+
+// Subscribe to the updates of an account, see RequestAccount to get its current state
+// param address - An account address, see base.ParseAddress
+// return - A channel of [AccountMetaDataPair] struct and a function to unsubscribe, closing the channel
+// link http://bob.nem.ninja/docs/#accountMetaDataPair
+func (c *Client) SubscribeAccount(address base.Address) (<-chan requests.AccountMetaDataPair, func(), error) {
+	if err := address.Validate(); err != nil {
+		return nil, nil, err
+	}
+	ch := make(chan requests.AccountMetaDataPair)
+	s, err := c.subscribe(accountChannel+address.String(), func(body []byte, done <-chan struct{}) error {
+		var data requests.AccountMetaDataPair
+		if err := json.Unmarshal(body, &data); err != nil {
+			return err
+		}
+		select {
+		case ch <- data:
+		case <-done:
+		}
+		return nil
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, nil, err
+	}
+	return ch, func() { c.unsubscribe(s) }, nil
+}
+
+// Subscribe to the transactions of an account once included in a block
+// param address - An account address, see base.ParseAddress
+// return - A channel of [TransactionMetaDataPair] struct and a function to unsubscribe, closing the channel
+// link http://bob.nem.ninja/docs/#transactionMetaDataPair
+func (c *Client) SubscribeConfirmed(address base.Address) (<-chan requests.TransactionMetaDataPair, func(), error) {
+	return c.subscribeTransactions(confirmedChannel, address)
+}
+
+// Subscribe to the transactions of an account as soon as they reach the node
+// param address - An account address, see base.ParseAddress
+// return - A channel of [TransactionMetaDataPair] struct and a function to unsubscribe, closing the channel
+// link http://bob.nem.ninja/docs/#unconfirmedTransactionMetaDataPair
+func (c *Client) SubscribeUnconfirmed(address base.Address) (<-chan requests.TransactionMetaDataPair, func(), error) {
+	return c.subscribeTransactions(unconfirmedChannel, address)
+}
+
+func (c *Client) subscribeTransactions(channel string, address base.Address) (<-chan requests.TransactionMetaDataPair, func(), error) {
+	if err := address.Validate(); err != nil {
+		return nil, nil, err
+	}
+	ch := make(chan requests.TransactionMetaDataPair)
+	s, err := c.subscribe(channel+address.String(), func(body []byte, done <-chan struct{}) error {
+		meta, tx, err := requests.MapTransaction(bytes.NewBuffer(body))
+		if err != nil {
+			return err
+		}
+		select {
+		case ch <- requests.TransactionMetaDataPair{Meta: *meta, Transaction: tx}:
+		case <-done:
+		}
+		return nil
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, nil, err
+	}
+	return ch, func() { c.unsubscribe(s) }, nil
+}
+
+// Ask NIS to publish the current state of an account on its channel (see SubscribeAccount)
+// param address - An account address, see base.ParseAddress
+func (c *Client) RequestAccount(address base.Address) error {
+	if err := address.Validate(); err != nil {
+		return err
+	}
+	body, err := json.Marshal(struct {
+		Account string `json:"account"`
+	}{address.String()})
+	if err != nil {
+		return err
+	}
+	return c.Send(accountGetEndpoint, body)
+}
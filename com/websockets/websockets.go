@@ -0,0 +1,483 @@
+// Package websockets subscribes to the NIS STOMP channels over a websocket:
+// new blocks, the chain height, account updates and the confirmed and
+// unconfirmed transactions of an address.
+package websockets // import "github.com/isarq/nem-sdk-go/com/websockets"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+// The websocket path of the NIS STOMP endpoint
+const Path = "/w/messages/websocket"
+
+// The default delay before the first reconnection attempt, it doubles up to MaxReconnectDelay
+const DefaultReconnectDelay = time.Second
+
+// The default maximum delay between two reconnection attempts
+const DefaultMaxReconnectDelay = 30 * time.Second
+
+// The number of messages queued for a subscription whose channel is not read,
+// the next messages are dropped and ErrDropped is reported on Errors
+const QueueSize = 64
+
+var (
+	ErrDropped          = errors.New("message dropped, the subscription channel is not read")
+	ErrClosed           = errors.New("websocket client closed")
+	ErrNotConnected     = errors.New("websocket client not connected")
+	ErrAlreadyConnected = errors.New("websocket client already connected")
+)
+
+// A Client keeps a STOMP session with a NIS node.
+// When the connection drops the client reconnects and subscribes again to every channel,
+// the subscriptions stay valid meanwhile. The fields must be set before Connect.
+// Each subscription has its own queue, a channel that is not read does not hold the others back.
+type Client struct {
+	// URL is the websocket URL of the node
+	URL url.URL
+	// Dialer opens the connections, websocket.DefaultDialer is used when nil
+	Dialer *websocket.Dialer
+	// ReconnectDelay is the delay before the first reconnection attempt
+	ReconnectDelay time.Duration
+	// MaxReconnectDelay is the maximum delay between two reconnection attempts
+	MaxReconnectDelay time.Duration
+
+	mu      sync.Mutex
+	conn    *websocket.Conn
+	subs    map[string]*subscription
+	nextID  int
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    chan struct{}
+	closed  bool
+	writeMu sync.Mutex
+	errs    chan error
+}
+
+// Create a websocket client for a node
+// param node - A NIS endpoint struct, the port is the websocket port (model.WebsocketPort)
+// return - A client point
+func NewClient(node base.Node) *Client {
+	scheme := "ws"
+	if strings.HasPrefix(node.Host, "https://") {
+		scheme = "wss"
+	}
+	c := &Client{
+		URL:               url.URL{Scheme: scheme, Host: utils.FormatEndpoint(node), Path: Path},
+		ReconnectDelay:    DefaultReconnectDelay,
+		MaxReconnectDelay: DefaultMaxReconnectDelay,
+		subs:              make(map[string]*subscription),
+		errs:              make(chan error, 16),
+	}
+	// The errors sent by NIS on the session are reported on Errors
+	c.subscribe("/errors", func(body []byte, _ <-chan struct{}) error {
+		return errors.New(string(body))
+	}, nil)
+	return c
+}
+
+// Errors reports the connection failures, the errors sent by NIS and the payloads that could
+// not be decoded. Errors are dropped when the channel is full, it is never closed.
+func (c *Client) Errors() <-chan error {
+	return c.errs
+}
+
+func (c *Client) report(err error) {
+	select {
+	case c.errs <- err:
+	default:
+	}
+}
+
+// Open the STOMP session and keep it open until Close is called
+// param ctx - Bounds the first connection only
+func (c *Client) Connect(ctx context.Context) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return ErrClosed
+	}
+	if c.done != nil {
+		c.mu.Unlock()
+		return ErrAlreadyConnected
+	}
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	c.done = make(chan struct{})
+	c.mu.Unlock()
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		c.mu.Lock()
+		c.cancel()
+		c.done = nil
+		c.mu.Unlock()
+		return err
+	}
+	go c.run(conn)
+	return nil
+}
+
+// Close the session and the channels of every subscription
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	conn, done := c.conn, c.done
+	if c.cancel != nil {
+		c.cancel()
+	}
+	subs := make([]*subscription, 0, len(c.subs))
+	for _, s := range c.subs {
+		subs = append(subs, s)
+	}
+	c.mu.Unlock()
+
+	var err error
+	if conn != nil {
+		c.writeMu.Lock()
+		conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+		c.writeMu.Unlock()
+		err = conn.Close()
+	}
+	// Stopping the subscriptions first ends their deliveries
+	for _, s := range subs {
+		s.stop()
+	}
+	if done != nil {
+		<-done
+	}
+	return err
+}
+
+// Dial the node, open the STOMP session and subscribe again to every channel
+func (c *Client) dial(ctx context.Context) (*websocket.Conn, error) {
+	dialer := c.Dialer
+	if dialer == nil {
+		dialer = websocket.DefaultDialer
+	}
+	conn, _, err := dialer.DialContext(ctx, c.URL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unblock the handshake when ctx ends
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	connect := frame{command: stompConnect, headers: map[string]string{
+		"accept-version": "1.1,1.0",
+		"heart-beat":     "0,0",
+		"host":           c.URL.Hostname(),
+	}}
+	if err := conn.WriteMessage(websocket.TextMessage, connect.encode()); err != nil {
+		conn.Close()
+		return nil, ctxErr(ctx, err)
+	}
+	if err := readConnected(conn); err != nil {
+		conn.Close()
+		return nil, ctxErr(ctx, err)
+	}
+
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		conn.Close()
+		return nil, ErrClosed
+	}
+	c.conn = conn
+	subs := make([]*subscription, 0, len(c.subs))
+	for _, s := range c.subs {
+		subs = append(subs, s)
+	}
+	c.mu.Unlock()
+
+	// A failed write is reported by the read loop, which reconnects
+	for _, s := range subs {
+		c.write(conn, s.subscribeFrame())
+	}
+	return conn, nil
+}
+
+func ctxErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// Wait for the CONNECTED frame answering CONNECT
+func readConnected(conn *websocket.Conn) error {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		frames, err := decodeFrames(data)
+		if err != nil {
+			return err
+		}
+		for _, f := range frames {
+			switch f.command {
+			case stompConnected:
+				return nil
+			case stompError:
+				return stompErr(f)
+			}
+		}
+	}
+}
+
+func stompErr(f frame) error {
+	msg := f.headers["message"]
+	if len(f.body) > 0 {
+		if msg != "" {
+			msg += ": "
+		}
+		msg += string(f.body)
+	}
+	return errors.New("stomp: " + msg)
+}
+
+// Read the messages and reconnect when the connection drops, until Close is called
+func (c *Client) run(conn *websocket.Conn) {
+	defer close(c.done)
+	for {
+		err := c.read(conn)
+		conn.Close()
+
+		c.mu.Lock()
+		if c.conn == conn {
+			c.conn = nil
+		}
+		c.mu.Unlock()
+		if c.ctx.Err() != nil {
+			return
+		}
+		c.report(err)
+
+		if conn = c.reconnect(); conn == nil {
+			return
+		}
+	}
+}
+
+func (c *Client) reconnect() *websocket.Conn {
+	delay := c.ReconnectDelay
+	if delay <= 0 {
+		delay = DefaultReconnectDelay
+	}
+	for {
+		t := time.NewTimer(delay)
+		select {
+		case <-c.ctx.Done():
+			t.Stop()
+			return nil
+		case <-t.C:
+		}
+
+		conn, err := c.dial(c.ctx)
+		if err == nil {
+			return conn
+		}
+		if c.ctx.Err() != nil {
+			return nil
+		}
+		c.report(err)
+
+		if delay *= 2; c.MaxReconnectDelay > 0 && delay > c.MaxReconnectDelay {
+			delay = c.MaxReconnectDelay
+		}
+	}
+}
+
+func (c *Client) read(conn *websocket.Conn) error {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		frames, err := decodeFrames(data)
+		if err != nil {
+			c.report(err)
+		}
+		for _, f := range frames {
+			switch f.command {
+			case stompMessage:
+				c.mu.Lock()
+				s := c.subs[f.headers["subscription"]]
+				c.mu.Unlock()
+				if s == nil {
+					continue
+				}
+				if err := s.push(f.body); err != nil {
+					c.report(err)
+				}
+			case stompError:
+				c.report(stompErr(f))
+			}
+		}
+	}
+}
+
+// Write a frame on conn, the writes are serialized
+func (c *Client) write(conn *websocket.Conn, f frame) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return conn.WriteMessage(websocket.TextMessage, f.encode())
+}
+
+// Send a frame on the current connection
+func (c *Client) send(f frame) error {
+	c.mu.Lock()
+	conn := c.conn
+	c.mu.Unlock()
+	if conn == nil {
+		return ErrNotConnected
+	}
+	return c.write(conn, f)
+}
+
+// Send a message to a NIS destination
+// param destination - The destination, e.g. "/w/api/account/get"
+// param body - The JSON body
+func (c *Client) Send(destination string, body []byte) error {
+	return c.send(frame{command: stompSend, headers: map[string]string{
+		"destination":  destination,
+		"content-type": "application/json",
+	}, body: body})
+}
+
+// Register a subscription and subscribe when connected
+// param destination - The channel
+// param handle - Decodes a message body and delivers it unless done is closed
+// param closeCh - Closes the channel of the subscription
+func (c *Client) subscribe(destination string, handle func(body []byte, done <-chan struct{}) error, closeCh func()) (*subscription, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, ErrClosed
+	}
+	c.nextID++
+	s := &subscription{
+		id:          "sub-" + strconv.Itoa(c.nextID),
+		destination: destination,
+		handle:      handle,
+		closeCh:     closeCh,
+		queue:       make(chan []byte, QueueSize),
+		done:        make(chan struct{}),
+	}
+	c.subs[s.id] = s
+	conn := c.conn
+	c.mu.Unlock()
+	go s.run(c.report)
+
+	if conn != nil {
+		// A failed write drops the connection, the subscription is sent again on reconnection
+		c.write(conn, s.subscribeFrame())
+	}
+	return s, nil
+}
+
+// Remove a subscription and close its channel
+func (c *Client) unsubscribe(s *subscription) {
+	c.mu.Lock()
+	_, ok := c.subs[s.id]
+	delete(c.subs, s.id)
+	conn := c.conn
+	c.mu.Unlock()
+
+	if ok && conn != nil {
+		c.write(conn, frame{command: stompUnsubscribe, headers: map[string]string{"id": s.id}})
+	}
+	s.stop()
+}
+
+type subscription struct {
+	id          string
+	destination string
+	handle      func(body []byte, done <-chan struct{}) error
+	closeCh     func()
+	queue       chan []byte
+
+	mu   sync.Mutex
+	done chan struct{}
+	once sync.Once
+}
+
+func (s *subscription) subscribeFrame() frame {
+	return frame{command: stompSubscribe, headers: map[string]string{
+		"id":          s.id,
+		"destination": s.destination,
+	}}
+}
+
+// Queue a message without blocking the read loop
+func (s *subscription) push(body []byte) error {
+	select {
+	case s.queue <- body:
+		return nil
+	case <-s.done:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrDropped, s.destination)
+	}
+}
+
+// Deliver the queued messages until the subscription stops
+func (s *subscription) run(report func(error)) {
+	for {
+		select {
+		case body := <-s.queue:
+			if err := s.deliver(body); err != nil {
+				report(err)
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Deliver a message, blocks until the subscriber reads it or unsubscribes
+func (s *subscription) deliver(body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-s.done:
+		return nil
+	default:
+	}
+	return s.handle(body, s.done)
+}
+
+// Stop the deliveries and close the channel
+func (s *subscription) stop() {
+	s.once.Do(func() {
+		close(s.done)
+		s.mu.Lock()
+		if s.closeCh != nil {
+			s.closeCh()
+		}
+		s.mu.Unlock()
+	})
+}
@@ -0,0 +1,228 @@
+package websockets
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+)
+
+// A STOMP stand-in handing every accepted connection to the test once CONNECTED is sent
+type testNode struct {
+	*httptest.Server
+	conns chan *websocket.Conn
+}
+
+func newTestNode(t *testing.T) *testNode {
+	n := &testNode{conns: make(chan *websocket.Conn, 4)}
+	var upgrader websocket.Upgrader
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != Path {
+			http.NotFound(w, r)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		f := readFrame(t, conn)
+		if f.command != stompConnect || f.headers["accept-version"] == "" {
+			t.Errorf("first frame = %+v, want CONNECT", f)
+		}
+		conn.WriteMessage(websocket.TextMessage, frame{command: stompConnected, headers: map[string]string{"version": "1.1"}}.encode())
+		n.conns <- conn
+	}))
+	t.Cleanup(n.Close)
+	return n
+}
+
+func (n *testNode) node() base.Node {
+	host, port, _ := net.SplitHostPort(n.Listener.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return base.Node{Host: "http://" + host, Port: p}
+}
+
+// Wait for the next connection
+func (n *testNode) accept(t *testing.T) *websocket.Conn {
+	t.Helper()
+	select {
+	case conn := <-n.conns:
+		return conn
+	case <-time.After(5 * time.Second):
+		t.Fatal("no connection")
+		return nil
+	}
+}
+
+func readFrame(t *testing.T, conn *websocket.Conn) frame {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Errorf("read: %v", err)
+		return frame{}
+	}
+	frames, err := decodeFrames(data)
+	if err != nil || len(frames) != 1 {
+		t.Errorf("decode %q: %v", data, err)
+		return frame{}
+	}
+	return frames[0]
+}
+
+// Read the SUBSCRIBE frames of a connection until destination is subscribed
+// return - The subscription id
+func readSubscription(t *testing.T, conn *websocket.Conn, destination string) string {
+	t.Helper()
+	for {
+		f := readFrame(t, conn)
+		if f.command != stompSubscribe {
+			t.Fatalf("frame = %+v, want SUBSCRIBE", f)
+		}
+		if f.headers["destination"] == destination {
+			return f.headers["id"]
+		}
+	}
+}
+
+func sendMessage(t *testing.T, conn *websocket.Conn, id, body string) {
+	t.Helper()
+	f := frame{command: stompMessage, headers: map[string]string{"subscription": id, "destination": newBlocksChannel}, body: []byte(body)}
+	if err := conn.WriteMessage(websocket.TextMessage, f.encode()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func receiveHeight(t *testing.T, heights <-chan requests.BlockHeight, want int64) {
+	t.Helper()
+	select {
+	case h := <-heights:
+		if h.Height != want {
+			t.Errorf("height = %d, want %d", h.Height, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no height %d", want)
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	n := newTestNode(t)
+	c := NewClient(n.node())
+	c.ReconnectDelay = 10 * time.Millisecond
+	defer c.Close()
+
+	heights, _, err := c.SubscribeHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Connect(ctx); err != ErrAlreadyConnected {
+		t.Errorf("second Connect: err = %v, want ErrAlreadyConnected", err)
+	}
+
+	conn := n.accept(t)
+	id := readSubscription(t, conn, newBlocksChannel)
+	sendMessage(t, conn, id, `{"height":5}`)
+	receiveHeight(t, heights, 5)
+
+	// The client reconnects and subscribes again with the same id
+	conn.Close()
+	conn = n.accept(t)
+	if again := readSubscription(t, conn, newBlocksChannel); again != id {
+		t.Errorf("subscription id = %s after reconnection, want %s", again, id)
+	}
+	sendMessage(t, conn, id, `{"height":6}`)
+	receiveHeight(t, heights, 6)
+	select {
+	case err := <-c.Errors():
+		if err == nil {
+			t.Error("nil error reported for the dropped connection")
+		}
+	default:
+		t.Error("the dropped connection is not reported")
+	}
+
+	// A subscription made while connected is sent at once
+	blocks, unsubscribe, err := c.SubscribeBlocks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocksID := readSubscription(t, conn, blocksChannel)
+	unsubscribe()
+	if f := readFrame(t, conn); f.command != stompUnsubscribe || f.headers["id"] != blocksID {
+		t.Errorf("frame = %+v, want UNSUBSCRIBE %s", f, blocksID)
+	}
+	if _, ok := <-blocks; ok {
+		t.Error("the channel is open after unsubscribing")
+	}
+
+	if err := c.Close(); err != nil {
+		t.Error(err)
+	}
+	if _, ok := <-heights; ok {
+		t.Error("the channel is open after Close")
+	}
+	if err := c.Connect(ctx); err != ErrClosed {
+		t.Errorf("Connect after Close: err = %v, want ErrClosed", err)
+	}
+}
+
+// A channel that is not read holds back neither the other channels nor Errors
+func TestUnreadSubscription(t *testing.T) {
+	n := newTestNode(t)
+	c := NewClient(n.node())
+	defer c.Close()
+
+	if _, _, err := c.SubscribeBlocks(); err != nil {
+		t.Fatal(err)
+	}
+	heights, _, err := c.SubscribeHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	conn := n.accept(t)
+	ids := make(map[string]string)
+	for len(ids) < 3 {
+		f := readFrame(t, conn)
+		if f.command != stompSubscribe {
+			t.Fatalf("frame = %+v, want SUBSCRIBE", f)
+		}
+		ids[f.headers["destination"]] = f.headers["id"]
+	}
+
+	// The blocks overflow the queue of their subscription
+	for i := 0; i < QueueSize+2; i++ {
+		sendMessage(t, conn, ids[blocksChannel], `{"height":1}`)
+	}
+	sendMessage(t, conn, ids[newBlocksChannel], `{"height":7}`)
+	receiveHeight(t, heights, 7)
+
+	sendMessage(t, conn, ids["/errors"], "boom")
+	var dropped, nisErr bool
+	for !dropped || !nisErr {
+		select {
+		case err := <-c.Errors():
+			dropped = dropped || errors.Is(err, ErrDropped)
+			nisErr = nisErr || err.Error() == "boom"
+		case <-time.After(5 * time.Second):
+			t.Fatalf("reported: dropped %v, NIS error %v", dropped, nisErr)
+		}
+	}
+}
@@ -1 +1,133 @@
 package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"github.com/isarq/nem-sdk-go/utils"
+	"golang.org/x/crypto/scrypt"
+)
+
+// The scrypt parameters of a sealed secret.
+// Standard takes about a second and 256 MB to unlock, Light is for tests and small devices.
+const (
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+	LightScryptN    = 1 << 12
+	LightScryptP    = 6
+
+	scryptR      = 8
+	scryptKeyLen = 32
+	sealSaltLen  = 32
+)
+
+// The algorithms of a SealedBox
+const (
+	KDFScrypt    = "scrypt"
+	CipherAESGCM = "aes-256-gcm"
+)
+
+// ErrDecrypt is returned when a sealed secret can not be opened, usually a wrong password
+var ErrDecrypt = errors.New("could not decrypt, wrong password ?")
+
+// The scrypt parameters of a SealedBox
+type ScryptParams struct {
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+	Salt string `json:"salt"`
+}
+
+// A secret encrypted with a password-derived key (scrypt) and an AEAD (AES-256-GCM)
+// The hexadecimal fields make it safe to store as JSON.
+type SealedBox struct {
+	KDF        string       `json:"kdf"`
+	KDFParams  ScryptParams `json:"kdfparams"`
+	Cipher     string       `json:"cipher"`
+	Nonce      string       `json:"nonce"`
+	Ciphertext string       `json:"ciphertext"`
+}
+
+// Encrypt a secret with a password
+// param secret - The secret
+// param password - A password
+// param additional - Data authenticated with the secret, e.g. an address, it must be given to Open
+// param n - The scrypt CPU/memory cost, e.g. StandardScryptN
+// param p - The scrypt parallelization, e.g. StandardScryptP
+// return - A SealedBox point
+func Seal(secret []byte, password string, additional []byte, n, p int) (*SealedBox, error) {
+	if len(secret) == 0 || password == "" {
+		return nil, errors.New("Missing argument !")
+	}
+	salt := make([]byte, sealSaltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	box := &SealedBox{
+		KDF:       KDFScrypt,
+		KDFParams: ScryptParams{N: n, R: scryptR, P: p, Salt: utils.Bt2Hex(salt)},
+		Cipher:    CipherAESGCM,
+	}
+	aead, err := box.aead(password)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	box.Nonce = utils.Bt2Hex(nonce)
+	box.Ciphertext = utils.Bt2Hex(aead.Seal(nil, nonce, secret, additional))
+	return box, nil
+}
+
+// Decrypt a sealed secret
+// param password - The password given to Seal
+// param additional - The data authenticated with the secret given to Seal
+// return - The secret, ErrDecrypt when the password or the additional data is wrong
+func (b *SealedBox) Open(password string, additional []byte) ([]byte, error) {
+	if password == "" {
+		return nil, errors.New("Missing argument !")
+	}
+	if b.Cipher != CipherAESGCM {
+		return nil, errors.New("Unsupported cipher " + b.Cipher + " !")
+	}
+	if !utils.IsHexadecimal(b.Nonce) || !utils.IsHexadecimal(b.Ciphertext) || len(b.Nonce)%2 != 0 || len(b.Ciphertext)%2 != 0 {
+		return nil, errors.New("Nonce and ciphertext must be hexadecimal only !")
+	}
+	aead, err := b.aead(password)
+	if err != nil {
+		return nil, err
+	}
+	nonce := utils.Hex2Bt(b.Nonce)
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.New("Invalid nonce length !")
+	}
+	secret, err := aead.Open(nil, nonce, utils.Hex2Bt(b.Ciphertext), additional)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return secret, nil
+}
+
+// Derive the key of a box and create its AEAD
+func (b *SealedBox) aead(password string) (cipher.AEAD, error) {
+	if b.KDF != KDFScrypt {
+		return nil, errors.New("Unsupported key derivation function " + b.KDF + " !")
+	}
+	if !utils.IsHexadecimal(b.KDFParams.Salt) || len(b.KDFParams.Salt)%2 != 0 {
+		return nil, errors.New("Salt must be hexadecimal only !")
+	}
+	key, err := scrypt.Key([]byte(password), utils.Hex2Bt(b.KDFParams.Salt), b.KDFParams.N, b.KDFParams.R, b.KDFParams.P, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
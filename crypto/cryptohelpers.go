@@ -1,11 +1,25 @@
 package crypto
 
 import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
 	"errors"
-	nacl "github.com/ereyes01/cryptohelper"
+	"io"
+
+	"github.com/isarq/nem-sdk-go/external/crypto/ed25519"
+	"github.com/isarq/nem-sdk-go/external/crypto/sha3"
 	"github.com/isarq/nem-sdk-go/utils"
 )
 
+const (
+	// The salt length in bytes of an encrypted message
+	saltBytes = 32
+	// The initialization vector length in bytes of an encrypted message
+	ivBytes = aes.BlockSize
+)
+
 // Encode a message
 // param senderPriv - A sender private key
 // param recipientPub - A recipient public key
@@ -26,9 +40,14 @@ func Encode(senderPriv, recipientPub, msg string) (string, error) {
 		return "", err
 	}
 	// Processing
-
-	iv, _ := nacl.RandomKey(16)
-	salt, _ := nacl.RandomKey(32)
+	iv := make([]byte, ivBytes)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return "", err
+	}
+	salt := make([]byte, saltBytes)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", err
+	}
 	encoded, err := encode(senderPriv, recipientPub, msg, iv, salt)
 	if err != nil {
 		return "", err
@@ -44,9 +63,9 @@ func Encode(senderPriv, recipientPub, msg string) (string, error) {
 // param iv - An initialization vector
 // param salt - A salt
 // return - The encoded message
-func encode(senderPriv, recipientPub, msg, iv, salt string) (string, error) {
+func encode(senderPriv, recipientPub, msg string, iv, salt []byte) (string, error) {
 	// Errors
-	if senderPriv == "" || recipientPub == "" || msg == "" || iv == "" || salt == "" {
+	if senderPriv == "" || recipientPub == "" || msg == "" || len(iv) == 0 || len(salt) == 0 {
 		err := errors.New("Missing argument !")
 		return "", err
 	}
@@ -58,29 +77,185 @@ func encode(senderPriv, recipientPub, msg, iv, salt string) (string, error) {
 		err := errors.New("Public key is not valid !")
 		return "", err
 	}
+	if len(iv) != ivBytes || len(salt) != saltBytes {
+		err := errors.New("Invalid iv or salt length !")
+		return "", err
+	}
 	// Processing
-	//sk := utils.Hex2BaReversed(senderPriv)
-	//pk := utils.Hex2Bt(recipientPub)
-	//var shared [32]byte
+	sk := utils.Hex2Bt(utils.FixPrivateKey(senderPriv))
+	pk := utils.Hex2Bt(recipientPub)
+	encKey, err := keyDerive(salt, sk, pk)
+	if err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(encKey[:])
+	if err != nil {
+		return "", err
+	}
+	plain := pkcs7Pad([]byte(msg), aes.BlockSize)
+	encrypted := make([]byte, len(plain))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted, plain)
+	// Result
+	return utils.Bt2Hex(salt) + utils.Bt2Hex(iv) + utils.Bt2Hex(encrypted), nil
+}
 
-	//r := key_derive(shared, salt, sk, pk)
-	//encKey := r
-	//encIv := {
-	//    iv: convert.ua2words(iv, 16)
-	//}
-	//encrypted := CryptoJS.AES.encrypt(CryptoJS.enc.Hex.parse(convert.utf8ToHex(msg)), encKey, encIv)
-	//// Result
-	//result := convert.ua2hex(salt) + convert.ua2hex(iv) + CryptoJS.enc.Hex.stringify(encrypted.ciphertext)
-	return "", nil
+// Decode an encrypted message payload
+// param recipientPriv - A recipient private key
+// param senderPub - A sender public key
+// param payload - An encrypted message payload (salt + iv + ciphertext) in hexadecimal
+// return - The decoded message
+func Decode(recipientPriv, senderPub, payload string) (string, error) {
+	// Errors
+	if recipientPriv == "" || senderPub == "" || payload == "" {
+		err := errors.New("Missing argument !")
+		return "", err
+	}
+	if !utils.IsPrivateKeyValid(recipientPriv) {
+		err := errors.New("Private key is not valid !")
+		return "", err
+	}
+	if !utils.IsPublicKeyValid(senderPub) {
+		err := errors.New("Public key is not valid !")
+		return "", err
+	}
+	if !utils.IsHexadecimal(payload) || len(payload)%2 != 0 {
+		err := errors.New("Payload must be hexadecimal only !")
+		return "", err
+	}
+	data := utils.Hex2Bt(payload)
+	if len(data) < saltBytes+ivBytes+aes.BlockSize || (len(data)-saltBytes-ivBytes)%aes.BlockSize != 0 {
+		err := errors.New("Payload is not a valid encrypted message !")
+		return "", err
+	}
+	// Processing
+	salt := data[:saltBytes]
+	iv := data[saltBytes : saltBytes+ivBytes]
+	encrypted := data[saltBytes+ivBytes:]
+	sk := utils.Hex2Bt(utils.FixPrivateKey(recipientPriv))
+	pk := utils.Hex2Bt(senderPub)
+	encKey, err := keyDerive(salt, sk, pk)
+	if err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(encKey[:])
+	if err != nil {
+		return "", err
+	}
+	plain := make([]byte, len(encrypted))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, encrypted)
+	msg, err := pkcs7Unpad(plain, aes.BlockSize)
+	if err != nil {
+		return "", err
+	}
+	// Result
+	return string(msg), nil
 }
 
-func keyDerive(shared [32]byte, salt, sk, pk string) {
-	//nacl.lowlevel.crypto_shared_key_hash(shared, pk, sk, hashfunc);
-	//for (let i = 0; i < salt.length; i++) {
-	//    shared[i] ^= salt[i];
-	//}
-	//let hash = CryptoJS.SHA3(convert.ua2words(shared, 32), {
-	//    outputLength: 256
-	//});
-	//return hash;
+// Derive a key from a password, as NanoWallet
+// The password is hashed count times with Keccak-256, e.g. 20 times for the
+// key protecting the private keys of a wallet and 6000 times for a brain wallet.
+// param password - A password
+// param count - The number of iterations
+// return - The derived key
+func DerivePassSha(password string, count int) []byte {
+	data := []byte(password)
+	for i := 0; i < count; i++ {
+		h := sha3.SumKeccak256(data)
+		data = h[:]
+	}
+	return data
+}
+
+// Encrypt a private key with a password, as NanoWallet
+// param privateKey - A private key
+// param password - A password
+// return - The encrypted private key and the initialization vector in hexadecimal
+func EncodePrivKey(privateKey, password string) (string, string, error) {
+	if privateKey == "" || password == "" {
+		return "", "", errors.New("Missing argument !")
+	}
+	if !utils.IsPrivateKeyValid(privateKey) {
+		return "", "", errors.New("Private key is not valid !")
+	}
+	iv := make([]byte, ivBytes)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return "", "", err
+	}
+	block, err := aes.NewCipher(DerivePassSha(password, 20))
+	if err != nil {
+		return "", "", err
+	}
+	plain := pkcs7Pad(utils.Hex2Bt(privateKey), aes.BlockSize)
+	encrypted := make([]byte, len(plain))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted, plain)
+	return utils.Bt2Hex(encrypted), utils.Bt2Hex(iv), nil
+}
+
+// Decrypt a private key encrypted with a password, as NanoWallet
+// A wrong password is usually reported as an invalid padding, the caller
+// should check the private key matches the expected address.
+// param encrypted - An encrypted private key in hexadecimal
+// param iv - An initialization vector in hexadecimal
+// param password - A password
+// return - The private key
+func DecodePrivKey(encrypted, iv, password string) (string, error) {
+	if encrypted == "" || iv == "" || password == "" {
+		return "", errors.New("Missing argument !")
+	}
+	if !utils.IsHexadecimal(encrypted) || !utils.IsHexadecimal(iv) || len(encrypted)%2 != 0 || len(iv) != 2*ivBytes {
+		return "", errors.New("Encrypted private key and iv must be hexadecimal only !")
+	}
+	data := utils.Hex2Bt(encrypted)
+	if len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return "", errors.New("Encrypted private key is not valid !")
+	}
+	block, err := aes.NewCipher(DerivePassSha(password, 20))
+	if err != nil {
+		return "", err
+	}
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, utils.Hex2Bt(iv)).CryptBlocks(plain, data)
+	key, err := pkcs7Unpad(plain, aes.BlockSize)
+	if err != nil {
+		return "", err
+	}
+	return utils.Bt2Hex(key), nil
+}
+
+// Derive the AES key of an encrypted message
+// param salt - A salt
+// param sk - A private key
+// param pk - A public key
+// return - The encryption key
+func keyDerive(salt, sk, pk []byte) ([32]byte, error) {
+	shared, err := ed25519.SharedKey(pk, sk)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	for i := 0; i < len(salt); i++ {
+		shared[i] ^= salt[i]
+	}
+	return sha3.SumKeccak256(shared[:]), nil
+}
+
+func pkcs7Pad(data []byte, blockSize int) []byte {
+	padding := blockSize - len(data)%blockSize
+	return append(data, bytes.Repeat([]byte{byte(padding)}, padding)...)
+}
+
+func pkcs7Unpad(data []byte, blockSize int) ([]byte, error) {
+	length := len(data)
+	if length == 0 || length%blockSize != 0 {
+		return nil, errors.New("Invalid padding !")
+	}
+	padding := int(data[length-1])
+	if padding == 0 || padding > blockSize {
+		return nil, errors.New("Invalid padding !")
+	}
+	for _, b := range data[length-padding:] {
+		if int(b) != padding {
+			return nil, errors.New("Invalid padding !")
+		}
+	}
+	return data[:length-padding], nil
 }
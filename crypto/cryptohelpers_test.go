@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+// The key pairs of the encrypted message vectors
+const (
+	senderPriv     = "2a91e1d5c110a8d0105aad4683f962c2a56663a3cad46666b16d243174673d90"
+	senderPub      = "9291abb3c52134be9d20ef21a796743497df7776d2661237bda9cadade34e44c"
+	recipientPriv  = "2618090794e9c9682f2ac6504369a2f4fb9fe7ee7746f9560aca228d355b1cb9"
+	recipientPub   = "5aae0b521c59cfc8c2114dc74d2f652359a68e377657c3f6bd6091f16f72e1ec"
+	message        = "NEM is awesome !"
+	messageSalt    = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+	messageIV      = "6465666768696a6b6c6d6e6f70717273"
+	messagePayload = messageSalt + messageIV + "2d704a3cd46e66f7b89efda8378936fef6c7efb29477687e01077acd479f72c5"
+)
+
+// The payload was computed outside of this package, with a reference of the nem-sdk
+// algorithm (Keccak-512 clamped key, nacl shared key, Keccak-256 of the salted key, AES-CBC)
+func TestEncodeKnownAnswer(t *testing.T) {
+	payload, err := encode(senderPriv, recipientPub, message, utils.Hex2Bt(messageIV), utils.Hex2Bt(messageSalt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload != messagePayload {
+		t.Errorf("encode = %s, want %s", payload, messagePayload)
+	}
+	msg, err := Decode(recipientPriv, senderPub, messagePayload)
+	if err != nil || msg != message {
+		t.Errorf("Decode = %q, %v, want %q", msg, err, message)
+	}
+	// The sender can read the message it sent
+	msg, err = Decode(senderPriv, recipientPub, messagePayload)
+	if err != nil || msg != message {
+		t.Errorf("Decode by the sender = %q, %v, want %q", msg, err, message)
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	for _, msg := range []string{"a", "exactly 16 bytes", "a longer message, with accents: é à ü, spanning blocks"} {
+		payload, err := Encode(senderPriv, recipientPub, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again, _ := Encode(senderPriv, recipientPub, msg); again == payload {
+			t.Errorf("Encode(%q) reused the salt and iv", msg)
+		}
+		decoded, err := Decode(recipientPriv, senderPub, payload)
+		if err != nil || decoded != msg {
+			t.Errorf("Decode(Encode(%q)) = %q, %v", msg, decoded, err)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	const otherPriv = "575dbb3062267eff57c970a336ebbc8fbcfe12c5bd3ed7bc11eb0481d7704ced"
+
+	// The last byte of the block before the last one flips the last padding byte to 0x11
+	data := utils.Hex2Bt(messagePayload)
+	data[len(data)-17] ^= 0x01
+	badPadding := utils.Bt2Hex(data)
+
+	for name, tc := range map[string]struct{ priv, pub, payload string }{
+		"wrong key":         {otherPriv, senderPub, messagePayload},
+		"truncated payload": {recipientPriv, senderPub, messagePayload[:len(messagePayload)-32]},
+		"partial block":     {recipientPriv, senderPub, messagePayload[:len(messagePayload)-2]},
+		"bad padding":       {recipientPriv, senderPub, badPadding},
+		"not hexadecimal":   {recipientPriv, senderPub, strings.Repeat("zz", 64)},
+		"invalid key":       {recipientPriv, "abcd", messagePayload},
+	} {
+		if msg, err := Decode(tc.priv, tc.pub, tc.payload); err == nil {
+			t.Errorf("%s: Decode = %q, want an error", name, msg)
+		}
+	}
+}
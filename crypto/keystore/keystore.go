@@ -0,0 +1,309 @@
+// Package keystore stores private keys encrypted at rest, one JSON file per account.
+//
+// The private keys are sealed with a password-derived key (scrypt) and AES-256-GCM,
+// the address of the account is authenticated with the key so a file can not be
+// swapped for another account.
+//
+//	ks, err := keystore.Open("/var/lib/wallet/keys")
+//	account, err := ks.Import(privateKey, password, model.Data.Testnet.ID, "treasury")
+//	kp, err := ks.Unlock(account.Address, password)
+package keystore // import "github.com/isarq/nem-sdk-go/crypto/keystore"
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/isarq/nem-sdk-go/crypto"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+// The version of the key files
+const Version = 1
+
+var (
+	ErrNotFound = errors.New("keystore: account not found")
+	ErrExists   = errors.New("keystore: account already exists")
+	// ErrWrongPassword is crypto.ErrDecrypt, a key which can not be decrypted
+	ErrWrongPassword = crypto.ErrDecrypt
+)
+
+// An Account is the public part of a stored key
+type Account struct {
+	Address   string `json:"address"`
+	PublicKey string `json:"publicKey"`
+	Network   int    `json:"network"`
+	Label     string `json:"label"`
+}
+
+// The content of a key file
+type keyFile struct {
+	Version int `json:"version"`
+	Account
+	Crypto crypto.SealedBox `json:"crypto"`
+}
+
+// A Keystore is a directory of encrypted keys, its methods are safe for concurrent use.
+type Keystore struct {
+	// ScryptN and ScryptP are the scrypt parameters of the keys stored from now on,
+	// crypto.StandardScryptN and crypto.StandardScryptP by default
+	ScryptN, ScryptP int
+
+	dir string
+	mu  sync.Mutex
+}
+
+// Open a keystore, creating its directory when missing
+// param dir - The directory of the key files
+// return - A Keystore point
+func Open(dir string) (*Keystore, error) {
+	if dir == "" {
+		return nil, errors.New("missing parameter !")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &Keystore{ScryptN: crypto.StandardScryptN, ScryptP: crypto.StandardScryptP, dir: dir}, nil
+}
+
+// Gets the stored accounts, sorted by address
+// return - A slice of Account struct
+func (ks *Keystore) Accounts() ([]Account, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	files, err := ioutil.ReadDir(ks.dir)
+	if err != nil {
+		return nil, err
+	}
+	var accounts []Account
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		key, err := ks.read(strings.TrimSuffix(f.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, key.Account)
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Address < accounts[j].Address })
+	return accounts, nil
+}
+
+// Gets a stored account
+// param address - An account address
+// return - An Account struct
+func (ks *Keystore) Account(address string) (Account, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	key, err := ks.read(address)
+	if err != nil {
+		return Account{}, err
+	}
+	return key.Account, nil
+}
+
+// Report if an account is stored
+// param address - An account address
+func (ks *Keystore) Has(address string) bool {
+	_, err := ks.Account(address)
+	return err == nil
+}
+
+// Create an account with a random private key
+// param password - The password encrypting the private key
+// param network - A network id
+// param label - A label of the account
+// return - The new Account struct
+func (ks *Keystore) Create(password string, network int, label string) (Account, error) {
+	kp, err := model.KeyPairCreate("")
+	if err != nil {
+		return Account{}, err
+	}
+	return ks.Import(kp.PrivateString(), password, network, label)
+}
+
+// Store a private key
+// param privateKey - A private key
+// param password - The password encrypting the private key
+// param network - A network id
+// param label - A label of the account
+// return - The new Account struct
+func (ks *Keystore) Import(privateKey, password string, network int, label string) (Account, error) {
+	if privateKey == "" || password == "" {
+		return Account{}, errors.New("missing parameter !")
+	}
+	if !utils.IsPrivateKeyValid(privateKey) {
+		return Account{}, errors.New("invalid private key !")
+	}
+	kp, err := model.KeyPairCreate(utils.FixPrivateKey(privateKey))
+	if err != nil {
+		return Account{}, err
+	}
+	address, err := model.ToAddress(kp.PublicString(), network)
+	if err != nil {
+		return Account{}, err
+	}
+	account := Account{Address: address, PublicKey: kp.PublicString(), Network: network, Label: label}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if _, err := os.Stat(ks.path(address)); err == nil {
+		return Account{}, ErrExists
+	}
+	if err := ks.seal(account, kp.Private, password); err != nil {
+		return Account{}, err
+	}
+	return account, nil
+}
+
+// Change the label of an account
+// param address - An account address
+// param label - The new label
+func (ks *Keystore) SetLabel(address, label string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	key, err := ks.read(address)
+	if err != nil {
+		return err
+	}
+	key.Label = label
+	return ks.write(key)
+}
+
+// Decrypt the key pair of an account
+// param address - An account address
+// param password - The password of the account
+// return - The KeyPair of the account, ErrWrongPassword when the password is wrong
+func (ks *Keystore) Unlock(address, password string) (*model.KeyPair, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	key, err := ks.read(address)
+	if err != nil {
+		return nil, err
+	}
+	return open(key, password)
+}
+
+// Change the password of an account, re-encrypting its private key
+// param address - An account address
+// param password - The current password
+// param newPassword - The new password
+func (ks *Keystore) ChangePassword(address, password, newPassword string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	key, err := ks.read(address)
+	if err != nil {
+		return err
+	}
+	kp, err := open(key, password)
+	if err != nil {
+		return err
+	}
+	return ks.seal(key.Account, kp.Private, newPassword)
+}
+
+// Delete an account, the password is required to avoid deleting a key by mistake
+// param address - An account address
+// param password - The password of the account
+func (ks *Keystore) Delete(address, password string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	key, err := ks.read(address)
+	if err != nil {
+		return err
+	}
+	if _, err := open(key, password); err != nil {
+		return err
+	}
+	return os.Remove(ks.path(key.Address))
+}
+
+func normalize(address string) string {
+	return strings.ToUpper(strings.Replace(address, "-", "", -1))
+}
+
+func (ks *Keystore) path(address string) string {
+	return filepath.Join(ks.dir, normalize(address)+".json")
+}
+
+// Read a key file. The lock must be held.
+func (ks *Keystore) read(address string) (*keyFile, error) {
+	address = normalize(address)
+	if address == "" || strings.ContainsAny(address, `/\.`) {
+		return nil, ErrNotFound
+	}
+	data, err := ioutil.ReadFile(ks.path(address))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var key keyFile
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, err
+	}
+	if key.Version != Version {
+		return nil, errors.New("keystore: unsupported key file version")
+	}
+	if normalize(key.Address) != address {
+		return nil, errors.New("keystore: key file of " + key.Address + " stored as " + address)
+	}
+	return &key, nil
+}
+
+// Encrypt and write a key. The lock must be held.
+func (ks *Keystore) seal(account Account, private []byte, password string) error {
+	box, err := crypto.Seal(private, password, []byte(account.Address), ks.ScryptN, ks.ScryptP)
+	if err != nil {
+		return err
+	}
+	return ks.write(&keyFile{Version: Version, Account: account, Crypto: *box})
+}
+
+// Write a key file atomically, readable by its owner only. The lock must be held.
+func (ks *Keystore) write(key *keyFile) error {
+	data, err := json.MarshalIndent(key, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(ks.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), ks.path(key.Address))
+}
+
+// Decrypt a key and check it matches its account
+func open(key *keyFile, password string) (*model.KeyPair, error) {
+	private, err := key.Crypto.Open(password, []byte(key.Address))
+	if err != nil {
+		return nil, err
+	}
+	kp, err := model.FromSeed(private)
+	if err != nil {
+		return nil, err
+	}
+	if kp.PublicString() != key.PublicKey {
+		return nil, errors.New("keystore: key of " + key.Address + " does not match its public key")
+	}
+	return &kp, nil
+}
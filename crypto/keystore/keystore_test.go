@@ -0,0 +1,77 @@
+package keystore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/crypto"
+	"github.com/isarq/nem-sdk-go/model"
+)
+
+func newKeystore(t *testing.T) *Keystore {
+	ks, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks.ScryptN, ks.ScryptP = crypto.LightScryptN, crypto.LightScryptP
+	return ks
+}
+
+func TestKeystore(t *testing.T) {
+	const privateKey = "8fac4fbc0c2d1d0b1d8d10c0e0f1a8b0f8f8e8d8c8b8a8988878685848382810"
+	ks := newKeystore(t)
+
+	account, err := ks.Import(privateKey, "secret", model.Data.Testnet.ID, "treasury")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Import(privateKey, "secret", model.Data.Testnet.ID, ""); !errors.Is(err, ErrExists) {
+		t.Errorf("Import twice: err = %v, want ErrExists", err)
+	}
+	if _, err := ks.Create("other", model.Data.Testnet.ID, "hot"); err != nil {
+		t.Fatal(err)
+	}
+
+	accounts, err := ks.Accounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 2 {
+		t.Fatalf("Accounts() returned %d accounts, want 2", len(accounts))
+	}
+
+	kp, err := ks.Unlock(account.Address, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kp.PrivateString() != privateKey {
+		t.Errorf("Unlock returned %s, want %s", kp.PrivateString(), privateKey)
+	}
+	if _, err := ks.Unlock(account.Address, "wrong"); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("Unlock with a wrong password: err = %v, want ErrWrongPassword", err)
+	}
+
+	if err := ks.SetLabel(account.Address, "cold"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.ChangePassword(account.Address, "secret", "new secret"); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := ks.Account(account.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Label != "cold" {
+		t.Errorf("label = %q, want cold", stored.Label)
+	}
+	if _, err := ks.Unlock(account.Address, "new secret"); err != nil {
+		t.Errorf("Unlock with the new password: %v", err)
+	}
+
+	if err := ks.Delete(account.Address, "new secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Unlock(account.Address, "new secret"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Unlock a deleted account: err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/model/objects"
+	"github.com/isarq/nem-sdk-go/utils"
+
+	"fmt"
+	"github.com/isarq/nem-sdk-go/model/transactions"
+)
+
+func main() {
+	// Create an NIS endpoint
+	endpoint := objects.Endpoint(model.DefaultTestnet, model.DefaultPort)
+	client := requests.NewClient(endpoint)
+
+	// Create a common object holding the key of the cosignatory
+	common := objects.GetCommon("", "265087519502bd6f6c93f74b189ecdea18da9f58ba9d83a425821e714ea2aeea", false)
+
+	kp, err := model.KeyPairCreate(common.PrivateKey)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	address, err := utils.PubToAddress(kp.PublicString(), model.Data.Testnet.ID)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Get the multisig transactions waiting for the signature of the cosignatory
+	pending, err := transactions.PendingSignatures(client, address, kp.PublicString())
+	if err != nil {
+		fmt.Println(utils.Struc2Json(err))
+		return
+	}
+
+	for _, p := range pending {
+		res, err := transactions.Cosign(common, p, client, model.Data.Testnet.ID)
+		if err != nil {
+			fmt.Println(utils.Struc2Json(err))
+			continue
+		}
+		fmt.Printf("Cosigned %s:\n%s", p.Meta.Data, utils.Struc2Json(res))
+	}
+
+	// A known transaction can be cosigned directly with its hash and the multisig address
+	//tx := objects.Signature("<multisig account address>", "<inner transaction hash>")
+	//transactionEntity, err := tx.Prepare(common, model.Data.Testnet.ID)
+	//res, err := transactions.Send(common, transactionEntity, client)
+}
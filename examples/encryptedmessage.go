@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/isarq/nem-sdk-go/crypto"
+	"github.com/isarq/nem-sdk-go/model"
+)
+
+func main() {
+	// Create sender and recipient keypairs
+	sender, _ := model.KeyPairCreate("")
+	recipient, _ := model.KeyPairCreate("")
+
+	// Encrypt a message for the recipient
+	payload, err := crypto.Encode(sender.PrivateString(), recipient.PublicString(), "NEM is awesome !")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("Encrypted payload: ", payload)
+
+	// Decrypt the message with the recipient private key
+	msg, err := crypto.Decode(recipient.PrivateString(), sender.PublicString(), payload)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("Decrypted message: ", msg)
+}
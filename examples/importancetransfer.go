@@ -0,0 +1,48 @@
+package main
+
+import (
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/model/objects"
+	"github.com/isarq/nem-sdk-go/utils"
+
+	"fmt"
+	"github.com/isarq/nem-sdk-go/model/transactions"
+)
+
+func main() {
+	// Create an NIS endpoint
+	endpoint := objects.Endpoint(model.DefaultTestnet, model.DefaultPort)
+	client := requests.NewClient(endpoint)
+
+	// Create a common object holding key
+	common := objects.GetCommon("", "265087519502bd6f6c93f74b189ecdea18da9f58ba9d83a425821e714ea2aeea", false)
+
+	// Create the remote account used to harvest on behalf of the main account
+	remote, _ := model.KeyPairCreate("")
+
+	// Link the remote account (transactions.ImportanceDeactivate to unlink it)
+	tx := objects.Importancetransfer(remote.PublicString(), transactions.ImportanceActivate)
+
+	// Enable Multisig
+	//tx.IsMultisig = true
+
+	// Publickey of the multifirm account (only if IsMultisig is true).
+	//tx.MultisigAccount = "00244b414eefef48a34de44fbdf613aeb5925e2d652a101924c43c7f91f60e0e"
+
+	transactionEntity, err := tx.Prepare(common, model.Data.Testnet.ID)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	res, err := transactions.Send(common, transactionEntity, client)
+	if err != nil {
+		fmt.Println(utils.Struc2Json(err))
+		return
+	}
+	fmt.Printf("ImportanceTransfer:\n%s", utils.Struc2Json(res))
+
+	// Once the link is active (after 360 blocks) start harvesting with the remote key
+	//err = client.StartHarvesting(remote.PrivateString())
+}
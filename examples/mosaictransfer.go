@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"github.com/isarq/nem-sdk-go/base"
 	"github.com/isarq/nem-sdk-go/com/requests"
 	"github.com/isarq/nem-sdk-go/model"
 	"github.com/isarq/nem-sdk-go/model/objects"
@@ -22,7 +23,8 @@ func main() {
 	mosaicDefinitionMetaDataPair := objects.MosaicDefinitionMetadataPair()
 	// Create an un-prepared mosaic transfer transaction struct
 	// (use same object as transfer tansaction)
-	tx := objects.Transfer("TCSBBN-7XUDLR-OZXZYJ-RCDZQC-33T3HE-FM3B4E-SESM", 1, "")
+	// (the amount is the multiplier of the mosaics, 1 XEM sends the attached quantities once)
+	tx := objects.Transfer("TCSBBN-7XUDLR-OZXZYJ-RCDZQC-33T3HE-FM3B4E-SESM", base.XEM, "")
 
 	//// Enable Multisig
 	//tx.IsMultisig = true
@@ -71,7 +73,11 @@ func main() {
 	mosaicDefinitionMetaDataPair[fullMosaicName] = neededDefinition[fullMosaicName]
 
 	// Prepare the transfer transaction object
-	transactionEntity := tx.PrepareMosaic(common, mosaicDefinitionMetaDataPair, client, model.Data.Testnet.ID)
+	transactionEntity, err := tx.PrepareMosaic(common, mosaicDefinitionMetaDataPair, client, model.Data.Testnet.ID)
+	if err != nil {
+		fmt.Println(utils.Struc2Json(err))
+		return
+	}
 
 	res, err := transactions.Send(common, transactionEntity, client)
 	if err != nil {
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/model/objects"
+	"github.com/isarq/nem-sdk-go/utils"
+
+	"fmt"
+	"github.com/isarq/nem-sdk-go/model/transactions"
+)
+
+func main() {
+	// Create an NIS endpoint
+	endpoint := objects.Endpoint(model.DefaultTestnet, model.DefaultPort)
+	client := requests.NewClient(endpoint)
+
+	// Create a common object holding the key of the account to convert
+	common := objects.GetCommon("", "265087519502bd6f6c93f74b189ecdea18da9f58ba9d83a425821e714ea2aeea", false)
+
+	// Get a MultisigAggregateModification struct
+	tx := objects.MultisigAggregateModification()
+
+	// Add the cosignatories (transactions.CosignatoryDelete to remove one)
+	tx.Modifications = []base.ConsModif{
+		objects.MultisigCosignatoryModification(transactions.CosignatoryAdd,
+			"31efa466d2c0aee147397ec3bbe16354fd6fc10eb6710014c8d9a8924ad9b152"),
+		objects.MultisigCosignatoryModification(transactions.CosignatoryAdd,
+			"00244b414eefef48a34de44fbdf613aeb5925e2d652a101924c43c7f91f60e0e"),
+		objects.MultisigCosignatoryModification(transactions.CosignatoryAdd,
+			"5aae0b521c59cfc8c2114dc74d2f0359a6e5f2f7ad31b2cdb9e7a8e0d9f1f4a9"),
+	}
+
+	// Require 2 of the 3 cosignatories
+	tx.RelativeChange = 2
+
+	// To change an existing multisig account, sign with a cosignatory key and
+	// check the modifications against the current state of the account:
+	//tx.IsMultisig = true
+	//tx.MultisigAccount = "<public key of the multisig account>"
+	//account, _ := client.AccountDataFromPublicKey(tx.MultisigAccount)
+	//err := tx.VerifyAccount(account)
+
+	transactionEntity, err := tx.Prepare(common, model.Data.Testnet.ID)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	res, err := transactions.Send(common, transactionEntity, client)
+	if err != nil {
+		fmt.Println(utils.Struc2Json(err))
+		return
+	}
+	fmt.Printf("MultisigAggregateModification:\n%s", utils.Struc2Json(res))
+}
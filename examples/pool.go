@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+func main() {
+	// Create a pool of the testnet nodes
+	pool := requests.NewPool(model.Nodes(model.TestnetNode, model.DefaultPort))
+
+	// Announce the transactions to the 2 best nodes
+	pool.Broadcast = 2
+
+	// Check the nodes now, then every minute
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool.Check(ctx)
+	go pool.Run(ctx, time.Minute)
+
+	for _, s := range pool.Status() {
+		fmt.Println(s.Node.Host, s.Healthy, s.Height, s.Latency, s.Err)
+	}
+
+	// Any request can be sent with fail over
+	var account requests.AccountMetaDataPair
+	err := pool.Do(ctx, func(c *requests.Client) (err error) {
+		account, err = c.AccountDataCtx(ctx, "TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S")
+		return err
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(utils.Struc2Json(account))
+
+	// The client of the best node can be used with the transactions package
+	client, err := pool.Client()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	height, err := client.Height()
+	fmt.Println(height.Height, err)
+}
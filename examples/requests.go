@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/isarq/nem-sdk-go/base"
 	"github.com/isarq/nem-sdk-go/com/requests"
 	"github.com/isarq/nem-sdk-go/model"
 	"github.com/isarq/nem-sdk-go/utils"
@@ -12,8 +13,8 @@ import (
 func main() {
 
 	// Address we'll use in some queries
-	address := "TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S"
-	address2 := "TD5OUIZXUYGWILTDDPLD64TK44HWFFQIPZRRXRIH"
+	address := base.MustParseAddress("TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S")
+	address2 := base.MustParseAddress("TD5OUIZXUYGWILTDDPLD64TK44HWFFQIPZRRXRIH")
 	publickey := "0257b05f601ff829fdff84956fb5e3c65470a62375a1cc285779edd5ca3b42f6"
 
 	// Create an NIS endpoint
@@ -39,7 +40,7 @@ func main() {
 	fmt.Printf("Account data:\n%s", utils.Struc2Json(c))
 
 	// 3 - Gets the AccountMetaDataPair of an slice of accounts
-	d, err := client.GetBatchAccountData([]string{address, address2})
+	d, err := client.GetBatchAccountData([]base.Address{address, address2})
 	if err != nil {
 		fmt.Println(utils.Struc2Json(err))
 		return
@@ -49,7 +50,7 @@ func main() {
 	// ******** HISTORICAL GETS ********
 
 	// 4 - Gets the AccountMetaDataPair of an account from a certain block.
-	e, err := client.GetBatchHistoricalAccountData([]string{address, address2},
+	e, err := client.GetBatchHistoricalAccountData([]base.Address{address, address2},
 		104688770)
 	if err != nil {
 		fmt.Println(utils.Struc2Json(err))
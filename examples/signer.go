@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/com/signer"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/model/objects"
+	"github.com/isarq/nem-sdk-go/model/transactions"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+func main() {
+	// The signing daemon holds the key, usually in a keystore (transactions.NewKeystoreSigner)
+	keys, err := transactions.NewMemorySigner("056862b3dffbfd67a78172cf04c6a917325f2325f40cd48eea736f40b8a96d58",
+		model.Data.Testnet.ID)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	daemon, err := signer.NewServer(keys)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	daemon.Token = "change me"
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	go http.Serve(l, daemon)
+
+	// The application only knows the daemon and the account
+	remote, err := signer.Dial(context.Background(), "http://"+l.Addr().String(), keys.PublicKey(),
+		signer.WithToken("change me"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	endpoint := objects.Endpoint(model.DefaultTestnet, model.DefaultPort)
+	client := requests.NewClient(endpoint)
+
+	tx := objects.Transfer("TD2YSVI5L2OKSLAPJBWN7XXFBKYCHVXMXY42GS64", 1*base.XEM, "Hello")
+	transactionEntity, err := tx.Prepare(remote, model.Data.Testnet.ID)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	res, err := transactions.Send(remote, transactionEntity, client)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Transfer signed by %s\n%s", remote.Address(), utils.Struc2Json(res))
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/isarq/nem-sdk-go/base"
 	"github.com/isarq/nem-sdk-go/com/requests"
 	"github.com/isarq/nem-sdk-go/model"
 	"github.com/isarq/nem-sdk-go/model/objects"
@@ -19,8 +20,9 @@ func main() {
 	// Create a common object holding key
 	common := objects.GetCommon("", "056862b3dffbfd67a78172cf04c6a917325f2325f40cd48eea736f40b8a96d58", false)
 
-	// Create an un-prepared transfer transaction
-	tx := objects.Transfer("TD2YSVI5L2OKSLAPJBWN7XXFBKYCHVXMXY42GS64", 1, "Hello")
+	// Create an un-prepared transfer transaction of 1 XEM
+	// (amounts are in micro-XEM, base.ParseAmount reads a decimal amount like "1.5")
+	tx := objects.Transfer("TD2YSVI5L2OKSLAPJBWN7XXFBKYCHVXMXY42GS64", 1*base.XEM, "Hello")
 
 	//// Enable Multisig
 	//tx.IsMultisig = true
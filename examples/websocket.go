@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/websockets"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/model/objects"
+)
+
+func main() {
+	// Create a websocket endpoint, NIS serves the websockets on model.WebsocketPort
+	endpoint := objects.Endpoint(model.DefaultTestnet, model.WebsocketPort)
+	client := websockets.NewClient(endpoint)
+	defer client.Close()
+
+	address := base.MustParseAddress("TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S")
+
+	heights, _, err := client.SubscribeHeight()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	account, _, err := client.SubscribeAccount(address)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	unconfirmed, _, err := client.SubscribeUnconfirmed(address)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	confirmed, _, err := client.SubscribeConfirmed(address)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Ask for the current state of the account
+	if err := client.RequestAccount(address); err != nil {
+		fmt.Println(err)
+	}
+
+	for {
+		select {
+		case h := <-heights:
+			fmt.Println("New block:", h.Height)
+		case a := <-account:
+			fmt.Println("Balance:", a.Account.Balance.Format(6))
+		case t := <-unconfirmed:
+			fmt.Println("Unconfirmed transaction:", t.Meta.Hash.Data)
+		case t := <-confirmed:
+			fmt.Println("Confirmed transaction:", t.Meta.Hash.Data, "at height", t.Meta.Height)
+		case err := <-client.Errors():
+			fmt.Println("Error:", err)
+		}
+	}
+}
@@ -197,3 +197,39 @@ func reverseBytes(input []byte) []byte {
 	//fmt.Printf("SALIDA: %v \n", output)
 	return output
 }
+
+// SharedKey derives the point shared between the private key seed and a foreign
+// public key, as done by crypto_shared_key_hash in the NEM flavour of nacl.
+// The seed is expected in the same byte order used by GenerateKey.
+func SharedKey(publicKey PublicKey, seed []byte) ([32]byte, error) {
+	var shared [32]byte
+	if l := len(publicKey); l != PublicKeySize {
+		return shared, errors.New("ed25519: bad public key length: " + strconv.Itoa(l))
+	}
+	if l := len(seed); l != 32 {
+		return shared, errors.New("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+
+	digest := sha3.SumKeccak512(reverseBytes(seed))
+	digest[0] &= 248
+	digest[31] &= 127
+	digest[31] |= 64
+
+	var A edwards25519.ExtendedGroupElement
+	var publicKeyBytes [32]byte
+	copy(publicKeyBytes[:], publicKey)
+	if !A.FromBytes(&publicKeyBytes) {
+		return shared, errors.New("ed25519: invalid public key")
+	}
+	// nacl unpacks the foreign key negated before the scalar multiplication
+	edwards25519.FeNeg(&A.X, &A.X)
+	edwards25519.FeNeg(&A.T, &A.T)
+
+	var scalar, zero [32]byte
+	copy(scalar[:], digest[:32])
+
+	var R edwards25519.ProjectiveGroupElement
+	edwards25519.GeDoubleScalarMultVartime(&R, &scalar, &A, &zero)
+	R.ToBytes(&shared)
+	return shared, nil
+}
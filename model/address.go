@@ -3,13 +3,10 @@ package model
 import (
 	"bytes"
 	"crypto/rand"
-	"encoding/base32"
 	"encoding/hex"
 	"fmt"
 	"github.com/isarq/nem-sdk-go/external/crypto/ed25519"
-	"github.com/isarq/nem-sdk-go/external/crypto/sha3"
 	"github.com/isarq/nem-sdk-go/utils"
-	"golang.org/x/crypto/ripemd160"
 	"io"
 	"strings"
 )
@@ -59,24 +56,11 @@ func Verify(publicKey, message, sig []byte) bool {
 // param networkId - A network id
 // return - The NEM address
 func ToAddress(publicKey string, networkId int) (string, error) {
-	pk, err := hex.DecodeString(strings.TrimSpace(publicKey)) //Python
+	address, err := utils.PubToAddress(publicKey, networkId)
 	if err != nil {
 		return "", err
 	}
-
-	h := sha3.SumKeccak256(pk)
-	networkPrefix := Id2Prefix(networkId)
-
-	md := ripemd160.New()
-	md.Write(h[:])
-
-	s := md.Sum(nil)
-
-	s = append([]byte{networkPrefix}, s...)
-	h = sha3.SumKeccak256(s)
-
-	address := append(s, h[:4]...)
-	return base32.StdEncoding.EncodeToString(address), nil
+	return string(address), nil
 }
 
 // KeyPairCreate generates a KeyPair using specified string 32 length or empty
@@ -0,0 +1,353 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+var (
+	ErrUnknownMosaic          = errors.New("unknown mosaic definition")
+	ErrUnknownTransactionType = errors.New("unknown transaction type")
+	ErrInsufficientFee        = errors.New("fee below the minimum fee")
+)
+
+// The full name of XEM, known by every FeeCalculator
+const XEMName = "nem:xem"
+
+// The supply of XEM in whole units
+const XEMSupply = 8999999999
+
+// A FeeSchedule holds the fee rules of an era of the chain
+type FeeSchedule struct {
+	// Name of the era
+	Name string
+	// FromHeight is the first height of the era, by network id
+	FromHeight map[int]int64
+	// Unit is the fee of 32 bytes of message and the smallest fee of a mosaic
+	Unit base.Amount
+	// TransferFee is the fee of a transfer of whole XEM
+	TransferFee func(numNem uint64) base.Amount
+	// Default is the fee of the multisig, cosignature, importance transfer and supply change transactions
+	Default base.Amount
+	// Namespace is the fee of the provision namespace and mosaic definition transactions
+	Namespace base.Amount
+	// MultisigModification and MultisigCosignatory make the fee of a multisig aggregate
+	// modification: MultisigModification + MultisigCosignatory per modification
+	MultisigModification base.Amount
+	MultisigCosignatory  base.Amount
+	// The rental fees of a root and of a sub namespace, and the creation fee of a mosaic
+	RootNamespaceRental base.Amount
+	SubNamespaceRental  base.Amount
+	MosaicCreation      base.Amount
+}
+
+// The fee schedule in force before the first fee fork
+var LegacyFees = FeeSchedule{
+	Name:       "legacy",
+	FromHeight: map[int]int64{104: 1, -104: 1, 96: 1},
+	Unit:       2 * base.XEM,
+	TransferFee: func(numNem uint64) base.Amount {
+		fee := uint64(math.Floor(99 * math.Atan(float64(numNem)/150000)))
+		if fee < 2 {
+			fee = 2
+		}
+		return base.Amount(fee) * base.XEM
+	},
+	Default:              6 * base.XEM,
+	Namespace:            108 * base.XEM,
+	MultisigModification: 10 * base.XEM,
+	MultisigCosignatory:  6 * base.XEM,
+	RootNamespaceRental:  50000 * base.XEM,
+	SubNamespaceRental:   5000 * base.XEM,
+	MosaicCreation:       50000 * base.XEM,
+}
+
+// The fee schedule in force between the first and the second fee fork,
+// the fees of CurrentFees with a fee unit of 1 XEM
+var FirstForkFees = FeeSchedule{
+	Name:       "first fork",
+	FromHeight: map[int]int64{104: 875000, -104: 342000, 96: 1},
+	Unit:       base.XEM,
+	TransferFee: func(numNem uint64) base.Amount {
+		return base.XEM * base.Amount(CalculateMinimum(numNem))
+	},
+	Default:              baseTransactionFee * base.XEM,
+	Namespace:            baseTransactionFee * base.XEM,
+	MultisigModification: 10 * base.XEM,
+	RootNamespaceRental:  5000 * base.XEM,
+	SubNamespaceRental:   200 * base.XEM,
+	MosaicCreation:       500 * base.XEM,
+}
+
+// The fee schedule in force since the second fee fork
+var CurrentFees = FeeSchedule{
+	Name:       "current",
+	FromHeight: map[int]int64{104: 1250000, -104: 572500, 96: 1},
+	Unit:       FeeUnit,
+	TransferFee: func(numNem uint64) base.Amount {
+		return FeeUnit * base.Amount(CalculateMinimum(numNem))
+	},
+	Default:              Multisigtransaction,
+	Namespace:            NamespaceAndMosaicCommon,
+	MultisigModification: MultisigAggregateModificationTransaction,
+	RootNamespaceRental:  RootProvisionNamespaceTransaction,
+	SubNamespaceRental:   SubProvisionNamespaceTransaction,
+	MosaicCreation:       MosaicDefinitionTransaction,
+}
+
+// The fee schedules from the oldest to the newest
+var FeeSchedules = []FeeSchedule{LegacyFees, FirstForkFees, CurrentFees}
+
+// A FeeCalculator computes the minimum fee of the transactions at a height of a network
+type FeeCalculator struct {
+	// Network is the network id
+	Network int
+	// Height selects the fee schedule, 0 for the current one
+	Height int64
+	// Definitions are the definitions of the attached mosaics by full name (e.g. "nem:xem")
+	Definitions map[string]base.MosaicDefinition
+	// Supplies are the supplies of the attached mosaics in whole units by full name,
+	// the initial supply of the definition when missing
+	Supplies map[string]uint64
+}
+
+// Create a fee calculator of the current fee schedule
+// param network - A network id
+// return - A FeeCalculator point
+func NewFeeCalculator(network int) *FeeCalculator {
+	return &FeeCalculator{
+		Network:     network,
+		Definitions: make(map[string]base.MosaicDefinition),
+		Supplies:    make(map[string]uint64),
+	}
+}
+
+// Add the definition and the current supply of a mosaic
+// param definition - A mosaic definition
+// param supply - The supply in whole units, 0 for the initial supply of the definition
+func (c *FeeCalculator) AddMosaic(definition base.MosaicDefinition, supply uint64) {
+	if c.Definitions == nil {
+		c.Definitions = make(map[string]base.MosaicDefinition)
+	}
+	if c.Supplies == nil {
+		c.Supplies = make(map[string]uint64)
+	}
+	name := utils.MosaicIdToName(definition.ID)
+	c.Definitions[name] = definition
+	if supply > 0 {
+		c.Supplies[name] = supply
+	}
+}
+
+// Gets the fee schedule of the height of the calculator
+func (c *FeeCalculator) Schedule() FeeSchedule {
+	schedule := FeeSchedules[len(FeeSchedules)-1]
+	if c.Height <= 0 {
+		return schedule
+	}
+	for _, s := range FeeSchedules {
+		from, ok := s.FromHeight[c.Network]
+		if !ok {
+			from = s.FromHeight[Data.Mijin.ID]
+		}
+		if c.Height >= from {
+			schedule = s
+		}
+	}
+	return schedule
+}
+
+// Calculate the minimum fee of a transaction
+// The fee of a multisig transaction is the fee of the wrapper only, see TotalFee.
+// param tx - A prepared transaction
+// return - The minimum fee
+func (c *FeeCalculator) MinimumFee(tx base.Transaction) (base.Amount, error) {
+	s := c.Schedule()
+	switch t := tx.(type) {
+	case *base.TransferTransaction:
+		fee := c.messageFee(s, t.Message)
+		if len(t.Mosaics) == 0 {
+			return fee + s.TransferFee(uint64(t.Amount/base.XEM)), nil
+		}
+		mosaics, err := c.mosaicsFee(s, t.Amount, t.Mosaics)
+		if err != nil {
+			return 0, err
+		}
+		return fee + mosaics, nil
+	case *base.TransactionMosaic:
+		// A transfer returned by NIS with its meta data
+		transfer := base.TransferTransaction{Amount: t.Amount}
+		if t.Message != nil {
+			transfer.Message = *t.Message
+		}
+		for _, m := range t.Mosaics {
+			transfer.Mosaics = append(transfer.Mosaics, base.Mosaic{MosaicID: m.MosaicID, Quantity: m.Quantity})
+		}
+		return c.MinimumFee(&transfer)
+	case *base.ImportanceTransferTransaction, *base.MultiSignTransaction,
+		*base.MultisigSignatureTransaction, *base.MosaicSupplyChangeTransaction:
+		return s.Default, nil
+	case *base.ProvisionNamespaceTransaction, *base.MosaicDefinitionCreationTransaction:
+		return s.Namespace, nil
+	case *base.MultisigAggregateModificationTransaction:
+		return s.MultisigModification + s.MultisigCosignatory*base.Amount(len(t.Modifications)), nil
+	}
+	return 0, ErrUnknownTransactionType
+}
+
+// Calculate the minimum fee paid for a transaction: the fee of a multisig wrapper
+// and of its inner transaction, the fee of the transaction otherwise
+// param tx - A prepared transaction
+// return - The minimum fee
+func (c *FeeCalculator) TotalFee(tx base.Transaction) (base.Amount, error) {
+	fee, err := c.MinimumFee(tx)
+	if err != nil {
+		return 0, err
+	}
+	if m, ok := tx.(*base.MultiSignTransaction); ok {
+		inner, ok := m.OtherTrans.(base.Transaction)
+		if !ok {
+			return 0, errors.New("missing inner transaction")
+		}
+		innerFee, err := c.MinimumFee(inner)
+		if err != nil {
+			return 0, err
+		}
+		fee += innerFee
+	}
+	return fee, nil
+}
+
+// Gets the account paying the fees of a transaction. As NIS, the multisig account pays
+// the fees of its multisig transactions and of their cosignatures, the signer pays otherwise.
+// param tx - A transaction
+// param network - A network id
+// return - The address of the paying account
+func Debtor(tx base.Transaction, network int) (base.Address, error) {
+	switch t := tx.(type) {
+	case *base.MultiSignTransaction:
+		inner, ok := t.OtherTrans.(base.Transaction)
+		if !ok {
+			return "", errors.New("missing inner transaction")
+		}
+		return Debtor(inner, network)
+	case *base.MultisigSignatureTransaction:
+		return base.ParseAddress(t.OtherAccount)
+	}
+	return utils.PubToAddress(tx.GetCommon().Signer, network)
+}
+
+// Calculate the minimum rental fee of a provision namespace transaction,
+// or creation fee of a mosaic definition transaction
+// param tx - A prepared transaction
+// return - The minimum rental fee, 0 for the other transactions
+func (c *FeeCalculator) RentalFee(tx base.Transaction) base.Amount {
+	s := c.Schedule()
+	switch t := tx.(type) {
+	case *base.ProvisionNamespaceTransaction:
+		if t.Parent != "" {
+			return s.SubNamespaceRental
+		}
+		return s.RootNamespaceRental
+	case *base.MosaicDefinitionCreationTransaction:
+		return s.MosaicCreation
+	}
+	return 0
+}
+
+// Check the fees of a transaction, and of its inner transaction for a multisig
+// param tx - A prepared transaction
+// return - ErrInsufficientFee when a fee is below its minimum
+func (c *FeeCalculator) Check(tx base.Transaction) error {
+	txs := []base.Transaction{tx}
+	if m, ok := tx.(*base.MultiSignTransaction); ok {
+		if inner, ok := m.OtherTrans.(base.Transaction); ok {
+			txs = append(txs, inner)
+		}
+	}
+	for _, t := range txs {
+		fee, err := c.MinimumFee(t)
+		if err != nil {
+			return err
+		}
+		if t.GetCommon().Fee < fee {
+			return ErrInsufficientFee
+		}
+		var rental base.Amount
+		switch r := t.(type) {
+		case *base.ProvisionNamespaceTransaction:
+			rental = r.RentalFee
+		case *base.MosaicDefinitionCreationTransaction:
+			rental = r.CreationFee
+		}
+		if rental < c.RentalFee(t) {
+			return ErrInsufficientFee
+		}
+	}
+	return nil
+}
+
+// Calculate the fee of a message. Unit per commenced 32 bytes of payload
+func (c *FeeCalculator) messageFee(s FeeSchedule, message base.Message) base.Amount {
+	if message.Payload == "" {
+		return 0
+	}
+	// The payload is in hex
+	return s.Unit * base.Amount(len(message.Payload)/2/32+1)
+}
+
+// Calculate the fee of the mosaics attached to a transfer
+func (c *FeeCalculator) mosaicsFee(s FeeSchedule, multiplier base.Amount, attached []base.Mosaic) (base.Amount, error) {
+	var total base.Amount
+	for _, m := range attached {
+		name := utils.MosaicIdToName(m.MosaicID)
+		divisibility, supply, err := c.mosaic(name)
+		if err != nil {
+			return 0, err
+		}
+
+		// The small business mosaics pay the smallest fee
+		fee := s.Unit
+		if supply > 10000 || divisibility != 0 {
+			maxMosaicQuantity := uint64(9000000000000000)
+			totalMosaicQuantity := supply * uint64(math.Pow10(divisibility))
+
+			// The quotient is an integer, as in NIS
+			var supplyRelatedAdjustment base.Amount
+			if totalMosaicQuantity > 0 && maxMosaicQuantity/totalMosaicQuantity > 0 {
+				supplyRelatedAdjustment = base.Amount(math.Floor(0.8 * math.Log(float64(maxMosaicQuantity/totalMosaicQuantity))))
+			}
+			numNem := CalculateXemEquivalent(multiplier, m.Quantity, supply, divisibility)
+			if transferFee := s.TransferFee(numNem); transferFee > s.Unit*(supplyRelatedAdjustment+1) {
+				fee = transferFee - s.Unit*supplyRelatedAdjustment
+			}
+		}
+		total += fee
+	}
+	return total, nil
+}
+
+// Gets the divisibility and the supply in whole units of a mosaic
+func (c *FeeCalculator) mosaic(name string) (int, uint64, error) {
+	definition, ok := c.Definitions[name]
+	if !ok {
+		if name == XEMName {
+			return base.XEMDivisibility, XEMSupply, nil
+		}
+		return 0, 0, ErrUnknownMosaic
+	}
+	properties := utils.Grep(definition.Properties)
+	divisibility, err := strconv.Atoi(properties["divisibility"])
+	if err != nil && properties["divisibility"] != "" {
+		return 0, 0, err
+	}
+	supply := c.Supplies[name]
+	if supply == 0 {
+		supply, _ = strconv.ParseUint(properties["initialSupply"], 10, 64)
+	}
+	return divisibility, supply, nil
+}
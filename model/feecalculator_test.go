@@ -0,0 +1,231 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/model/transactions"
+)
+
+const (
+	signerKey = "0e0f2b5d5d2a1e9ba3d4dd8f1bb6e0be5b7c8f1c29e1b9b3a55f23d44a8fa4d1"
+	recipient = "TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S"
+)
+
+func TestMinimumFee(t *testing.T) {
+	signer, err := transactions.NewMemorySigner(signerKey, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := model.NewFeeCalculator(model.Data.Testnet.ID)
+
+	for _, tc := range []struct {
+		amount  base.Amount
+		message string
+		want    base.Amount
+	}{
+		{base.XEM, "", model.FeeUnit},
+		{20000 * base.XEM, "", 2 * model.FeeUnit},
+		{1000000 * base.XEM, "", 25 * model.FeeUnit},
+		{base.XEM, "hello", 2 * model.FeeUnit},
+		// NIS charges 0.05 XEM per commenced 32 bytes of message
+		{base.XEM, strings.Repeat("z", 31), 2 * model.FeeUnit},
+		{base.XEM, strings.Repeat("z", 32), 3 * model.FeeUnit},
+		{base.XEM, strings.Repeat("z", 64), 4 * model.FeeUnit},
+	} {
+		tx := transactions.Transfer{Amount: tc.amount, Recipient: recipient, Message: tc.message}
+		prepared, err := tx.Prepare(signer, model.Data.Testnet.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fee, err := c.MinimumFee(prepared)
+		if err != nil || fee != tc.want {
+			t.Errorf("MinimumFee(%d, %q) = %d, %v, want %d", tc.amount, tc.message, fee, err, tc.want)
+		}
+		if fee != prepared.GetCommon().Fee {
+			t.Errorf("MinimumFee(%d, %q) = %d, the prepared fee is %d", tc.amount, tc.message, fee, prepared.GetCommon().Fee)
+		}
+		if err := c.Check(prepared); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// A multisig transaction pays for the wrapper and the inner transaction
+	tx := transactions.Transfer{Amount: base.XEM, Recipient: recipient, IsMultisig: true, MultisigAccount: signer.PublicKey()}
+	multisig, err := tx.Prepare(signer, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee, err := c.TotalFee(multisig); err != nil || fee != model.Multisigtransaction+model.FeeUnit {
+		t.Errorf("TotalFee = %d, %v, want %d", fee, err, model.Multisigtransaction+model.FeeUnit)
+	}
+	multisig.(*base.MultiSignTransaction).OtherTrans.(*base.TransferTransaction).Fee = 0
+	if err := c.Check(multisig); err != model.ErrInsufficientFee {
+		t.Errorf("Check of an inner transaction without fee = %v", err)
+	}
+
+	supply := transactions.MosaicSupply{Mosaic: base.MosaicID{NamespaceID: "foo", Name: "bar"}, SupplyType: 1, Delta: 1}
+	prepared, err := supply.Prepare(signer, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee, err := c.MinimumFee(prepared); err != nil || fee != model.Multisigtransaction {
+		t.Errorf("MinimumFee(supply change) = %d, %v", fee, err)
+	}
+}
+
+func TestMosaicFee(t *testing.T) {
+	c := model.NewFeeCalculator(model.Data.Testnet.ID)
+	transfer := &base.TransferTransaction{
+		Amount:  base.XEM,
+		Mosaics: []base.Mosaic{{MosaicID: base.MosaicID{NamespaceID: "nem", Name: "xem"}, Quantity: 150000 * base.XEM}},
+	}
+	if fee, err := c.MinimumFee(transfer); err != nil || fee != 15*model.FeeUnit {
+		t.Errorf("MinimumFee(150000 nem:xem) = %d, %v, want %d", fee, err, 15*model.FeeUnit)
+	}
+
+	transfer.Mosaics = append(transfer.Mosaics, base.Mosaic{MosaicID: base.MosaicID{NamespaceID: "foo", Name: "bar"}, Quantity: 1})
+	if _, err := c.MinimumFee(transfer); err != model.ErrUnknownMosaic {
+		t.Errorf("MinimumFee of an unknown mosaic: err = %v, want ErrUnknownMosaic", err)
+	}
+
+	// A small business mosaic pays the smallest fee
+	c.AddMosaic(base.MosaicDefinition{
+		ID: base.MosaicID{NamespaceID: "foo", Name: "bar"},
+		Properties: []base.Properties{
+			{Name: "divisibility", Value: "0"},
+			{Name: "initialSupply", Value: "10000"},
+		},
+	}, 0)
+	if fee, err := c.MinimumFee(transfer); err != nil || fee != 16*model.FeeUnit {
+		t.Errorf("MinimumFee = %d, %v, want %d", fee, err, 16*model.FeeUnit)
+	}
+
+	// The supply adjustment is floor(0.8 * ln(9e15 / (supply * 10^divisibility))) with an integer quotient
+	for _, tc := range []struct {
+		supply string
+		want   base.Amount
+	}{
+		// 9e15 / 2.5e15 is 3, no adjustment where 3.6 would give 1
+		{"2500000000", 25 * model.FeeUnit},
+		{"1000000000", 24 * model.FeeUnit},
+	} {
+		c := model.NewFeeCalculator(model.Data.Testnet.ID)
+		big := base.MosaicID{NamespaceID: "foo", Name: "big"}
+		c.AddMosaic(base.MosaicDefinition{
+			ID: big,
+			Properties: []base.Properties{
+				{Name: "divisibility", Value: "6"},
+				{Name: "initialSupply", Value: tc.supply},
+			},
+		}, 0)
+		transfer := &base.TransferTransaction{Amount: base.XEM, Mosaics: []base.Mosaic{{MosaicID: big, Quantity: 100000 * base.XEM}}}
+		if fee, err := c.MinimumFee(transfer); err != nil || fee != tc.want {
+			t.Errorf("MinimumFee with a supply of %s = %d, %v, want %d", tc.supply, fee, err, tc.want)
+		}
+	}
+}
+
+func TestFeeSchedule(t *testing.T) {
+	transfer := &base.TransferTransaction{Amount: base.XEM}
+	namespace := &base.ProvisionNamespaceTransaction{}
+	for _, network := range []int{model.Data.Mainnet.ID, model.Data.Testnet.ID} {
+		first, second := model.FirstForkFees.FromHeight[network], model.CurrentFees.FromHeight[network]
+		for _, tc := range []struct {
+			height int64
+			fee    base.Amount
+			rental base.Amount
+		}{
+			{0, model.FeeUnit, 100 * base.XEM},
+			{1, 2 * base.XEM, 50000 * base.XEM},
+			{first - 1, 2 * base.XEM, 50000 * base.XEM},
+			{first, base.XEM, 5000 * base.XEM},
+			{second - 1, base.XEM, 5000 * base.XEM},
+			{second, model.FeeUnit, 100 * base.XEM},
+		} {
+			c := model.FeeCalculator{Network: network, Height: tc.height}
+			if fee, err := c.MinimumFee(transfer); err != nil || fee != tc.fee {
+				t.Errorf("network %d: MinimumFee at %d = %d, %v, want %d", network, tc.height, fee, err, tc.fee)
+			}
+			if rental := c.RentalFee(namespace); rental != tc.rental {
+				t.Errorf("network %d: RentalFee at %d = %d, want %d", network, tc.height, rental, tc.rental)
+			}
+		}
+	}
+	if c := (model.FeeCalculator{Network: model.Data.Mainnet.ID, Height: 875000}); c.Schedule().Name != model.FirstForkFees.Name {
+		t.Errorf("Schedule at 875000 = %s", c.Schedule().Name)
+	}
+}
+
+func TestXemEquivalent(t *testing.T) {
+	// 8999999999 * 1 * 1000000 / 1000 / 10^6 is 8999999.999, NIS rounds down
+	if numNem := model.CalculateXemEquivalent(base.XEM, 1, 1000, 0); numNem != 8999999 {
+		t.Errorf("CalculateXemEquivalent = %d, want 8999999", numNem)
+	}
+	if numNem := model.CalculateXemEquivalent(base.XEM, base.Quantity(base.XEM), model.XEMSupply, base.XEMDivisibility); numNem != 1 {
+		t.Errorf("CalculateXemEquivalent(1 nem:xem) = %d, want 1", numNem)
+	}
+	if numNem := model.CalculateXemEquivalent(base.XEM, 1, 0, 0); numNem != 0 {
+		t.Errorf("CalculateXemEquivalent without supply = %d, want 0", numNem)
+	}
+}
+
+func TestLevy(t *testing.T) {
+	levy := base.Levy{Type: model.LevyAbsolute, Fee: 5}
+	if q := model.CalculateLevy(levy, 1000); q != 5 {
+		t.Errorf("absolute levy = %d, want 5", q)
+	}
+	// 2.5% of the transferred quantity, 10 times the attached quantity
+	levy = base.Levy{Type: model.LevyPercentile, Fee: 250}
+	if q := model.CalculateLevy(levy, model.TransferredQuantity(10*base.XEM, 400)); q != 100 {
+		t.Errorf("percentile levy = %d, want 100", q)
+	}
+
+	attached := []base.Mosaic{{MosaicID: base.MosaicID{NamespaceID: "nem", Name: "xem"}, Quantity: base.XEM}}
+	if levies, err := model.CalculateLevies(base.XEM, nil, attached); err != nil || len(levies) != 0 {
+		t.Errorf("CalculateLevies(nem:xem) = %v, %v", levies, err)
+	}
+	attached = append(attached, base.Mosaic{MosaicID: base.MosaicID{NamespaceID: "foo", Name: "bar"}, Quantity: 1})
+	if _, err := model.CalculateLevies(base.XEM, nil, attached); err != model.ErrUnknownMosaic {
+		t.Errorf("CalculateLevies of an unknown mosaic: err = %v, want ErrUnknownMosaic", err)
+	}
+}
+
+func TestDebtor(t *testing.T) {
+	signer, err := transactions.NewMemorySigner(signerKey, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const multisigKey = "a1aaca6c17a24252e674d155713cdf55996ad00175be4af02a20c67b59f9fe8a"
+	tx := transactions.Transfer{Amount: base.XEM, Recipient: recipient}
+	transfer, err := tx.Prepare(signer, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.IsMultisig, tx.MultisigAccount = true, multisigKey
+	multisig, err := tx.Prepare(signer, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cosignature := &base.MultisigSignatureTransaction{OtherAccount: "TBCI2A-67UQZA-KCR6NS-4JWAEI-CEIGEI-M72G3M-VW5S"}
+
+	multisigAddress, _ := model.ToAddress(multisigKey, model.Data.Testnet.ID)
+	signerAddress, _ := model.ToAddress(signer.PublicKey(), model.Data.Testnet.ID)
+	for name, tc := range map[string]struct {
+		tx   base.Transaction
+		want string
+	}{
+		"transfer":    {transfer, signerAddress},
+		"multisig":    {multisig, multisigAddress},
+		"cosignature": {cosignature, recipient},
+	} {
+		if debtor, err := model.Debtor(tc.tx, model.Data.Testnet.ID); err != nil || debtor.String() != tc.want {
+			t.Errorf("Debtor(%s) = %s, %v, want %s", name, debtor, err, tc.want)
+		}
+	}
+	if _, err := model.Debtor(&base.MultiSignTransaction{}, model.Data.Testnet.ID); err == nil {
+		t.Error("Debtor of a multisig without inner transaction: no error")
+	}
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"math/bits"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+const (
+	// An absolute levy: the levy fee is paid for every transfer of the mosaic
+	LevyAbsolute = 1
+	// A percentile levy: the levy fee is in 1/10000 of the transferred quantity
+	LevyPercentile = 2
+)
+
+// Calculate the quantity of a mosaic moved by a transfer
+// param multiplier - The amount of the transfer, 1000000 for the quantity as given
+// param q - The attached quantity
+// return - The transferred quantity, in the smallest unit of the mosaic
+func TransferredQuantity(multiplier base.Amount, q base.Quantity) base.Quantity {
+	hi, lo := bits.Mul64(uint64(q), uint64(multiplier))
+	if hi >= uint64(base.XEM) {
+		return base.Quantity(^uint64(0))
+	}
+	quo, _ := bits.Div64(hi, lo, uint64(base.XEM))
+	return base.Quantity(quo)
+}
+
+// Calculate the levy of a mosaic transfer
+// param levy - The levy of the transferred mosaic definition
+// param q - The transferred quantity, see TransferredQuantity
+// return - The quantity of the levy mosaic paid to the levy recipient, 0 without levy
+func CalculateLevy(levy base.Levy, q base.Quantity) base.Quantity {
+	switch levy.Type {
+	case LevyAbsolute:
+		return levy.Fee
+	case LevyPercentile:
+		hi, lo := bits.Mul64(uint64(q), uint64(levy.Fee))
+		if hi >= 10000 {
+			return base.Quantity(^uint64(0))
+		}
+		quo, _ := bits.Div64(hi, lo, 10000)
+		return base.Quantity(quo)
+	}
+	return 0
+}
+
+// Calculate the levies of the mosaics attached to a transfer
+// param multiplier - The amount of the transfer, the multiplier of the mosaic quantities
+// param mosaics - The definitions of the attached mosaics by full name (e.g. "nem:xem")
+// param attachedMosaics - An array of mosaics to send
+// return - The levies of the attached mosaics with a levy, ErrUnknownMosaic when a definition is missing
+func CalculateLevies(multiplier base.Amount, mosaics map[string]base.MosaicDefinition,
+	attachedMosaics []base.Mosaic) ([]base.MosaicLevy, error) {
+	var levies []base.MosaicLevy
+	for _, m := range attachedMosaics {
+		name := utils.MosaicIdToName(m.MosaicID)
+		definition, ok := mosaics[name]
+		if !ok {
+			// XEM has no levy
+			if name == XEMName {
+				continue
+			}
+			return nil, ErrUnknownMosaic
+		}
+		if definition.Levy.Type == 0 {
+			continue
+		}
+		levies = append(levies, base.MosaicLevy{
+			Mosaic:   m.MosaicID,
+			Levy:     definition.Levy,
+			Quantity: CalculateLevy(definition.Levy, TransferredQuantity(multiplier, m.Quantity)),
+		})
+	}
+	return levies, nil
+}
+
+// Calculate the levies paid for a transaction, the levies of the inner transfer of a multisig
+// param tx - A prepared transaction
+// return - The levies, none for a transaction without levied mosaics
+func (c *FeeCalculator) Levies(tx base.Transaction) ([]base.MosaicLevy, error) {
+	switch t := tx.(type) {
+	case *base.TransferTransaction:
+		return CalculateLevies(t.Amount, c.Definitions, t.Mosaics)
+	case *base.TransactionMosaic:
+		attached := make([]base.Mosaic, 0, len(t.Mosaics))
+		for _, m := range t.Mosaics {
+			attached = append(attached, base.Mosaic{MosaicID: m.MosaicID, Quantity: m.Quantity})
+		}
+		return CalculateLevies(t.Amount, c.Definitions, attached)
+	case *base.MultiSignTransaction:
+		if inner, ok := t.OtherTrans.(base.Transaction); ok {
+			return c.Levies(inner)
+		}
+	}
+	return nil, nil
+}
@@ -17,13 +17,7 @@ var Data = base.Data{
 // param id - A network id
 // return - The network prefix
 func Id2Prefix(id int) byte {
-	if id == 104 {
-		return 0x68
-	} else if id == -104 {
-		return 0x98
-	} else {
-		return 0x60
-	}
+	return base.NetworkPrefix(id)
 }
 
 // Gets the starting char of the addresses of a network id
@@ -1,5 +1,7 @@
 package model
 
+import "github.com/isarq/nem-sdk-go/base"
+
 type SearchTestnet struct {
 	Url, Location string
 }
@@ -46,19 +48,19 @@ var SearchOnTestnet = []SearchTestnet{
 // type slice
 var SearchOnMainnet = []SearchTestnet{
 	{
-		Url:      `http: //62.75.171.41`,
+		Url:      `http://62.75.171.41`,
 		Location: `Germany`,
 	}, {
-		Url:      `http: //104.251.212.131`,
+		Url:      `http://104.251.212.131`,
 		Location: `USA`,
 	}, {
-		Url:      `http: //45.124.65.125`,
+		Url:      `http://45.124.65.125`,
 		Location: `Hong Kong`,
 	}, {
-		Url:      `http: //185.53.131.101`,
+		Url:      `http://185.53.131.101`,
 		Location: `Netherlands`,
 	}, {
-		Url:      `http: //sz.nemchina.com`,
+		Url:      `http://sz.nemchina.com`,
 		Location: `China`,
 	},
 }
@@ -76,28 +78,43 @@ var SearchOnMijin = []SearchTestnet{
 // type slice
 var TestnetNode = []NetNode{
 	{Uri: `http://104.128.226.60`},
-	{Uri: `http: //23.228.67.85`},
-	{Uri: `http: //192.3.61.243`},
-	{Uri: `http: //50.3.87.123`},
-	{Uri: `http: //localhost`},
+	{Uri: `http://23.228.67.85`},
+	{Uri: `http://192.3.61.243`},
+	{Uri: `http://50.3.87.123`},
+	{Uri: `http://localhost`},
 }
 
 // The mainnet nodes
 // type slice
 var MainnetNode = []NetNode{
-	{Uri: `http: //62.75.171.41`},
-	{Uri: `ttp: //san.nem.ninja`},
-	{Uri: `http: //go.nem.ninja`},
-	{Uri: `http: //hachi.nem.ninja`},
-	{Uri: `http: //jusan.nem.ninja`},
-	{Uri: `http: //nijuichi.nem.ninja`},
-	{Uri: `http: //alice2.nem.ninja`},
-	{Uri: `http: //alice3.nem.ninja`},
-	{Uri: `http: //alice4.nem.ninja`},
-	{Uri: `http: //alice5.nem.ninja`},
-	{Uri: `http: //alice6.nem.ninja`},
-	{Uri: `http: //alice7.nem.ninja`},
-	{Uri: `http: //localhost`},
+	{Uri: `http://62.75.171.41`},
+	{Uri: `http://san.nem.ninja`},
+	{Uri: `http://go.nem.ninja`},
+	{Uri: `http://hachi.nem.ninja`},
+	{Uri: `http://jusan.nem.ninja`},
+	{Uri: `http://nijuichi.nem.ninja`},
+	{Uri: `http://alice2.nem.ninja`},
+	{Uri: `http://alice3.nem.ninja`},
+	{Uri: `http://alice4.nem.ninja`},
+	{Uri: `http://alice5.nem.ninja`},
+	{Uri: `http://alice6.nem.ninja`},
+	{Uri: `http://alice7.nem.ninja`},
+	{Uri: `http://localhost`},
+}
+
+// Create the endpoints of a list of nodes
+// param nodes - A list of nodes, e.g. TestnetNode
+// param port - The port of the endpoints, e.g. DefaultPort
+// return - A slice of NIS endpoint struct
+func Nodes(nodes []NetNode, port int) []base.Node {
+	endpoints := make([]base.Node, 0, len(nodes))
+	for _, n := range nodes {
+		if n.Uri == "" {
+			continue
+		}
+		endpoints = append(endpoints, base.Node{Host: n.Uri, Port: port})
+	}
+	return endpoints
 }
 
 // The mijin nodes
@@ -7,9 +7,9 @@ import (
 // A mosaic attachment object
 // param namespaceId - A namespace name
 // param mosaicName - A mosaic name
-// param quantity - A mosaic quantity (in uXEM)
+// param quantity - A mosaic quantity in the smallest unit of the mosaic
 // return
-func Attachment(namespaceId, mosaicName string, quantity float64) base.Mosaic {
+func Attachment(namespaceId, mosaicName string, quantity base.Quantity) base.Mosaic {
 	return base.Mosaic{
 		MosaicID: base.MosaicID{
 			NamespaceID: namespaceId,
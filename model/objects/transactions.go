@@ -3,15 +3,14 @@ package objects
 import (
 	"github.com/isarq/nem-sdk-go/base"
 	"github.com/isarq/nem-sdk-go/model/transactions"
-	"strings"
 )
 
 // An un-prepared transfer transaction object
 // param recipient - A NEM account address
-// param amount - A number of XEM
+// param amount - An amount of micro-XEM (e.g. 2 * base.XEM), the multiplier for mosaic transfers
 // param message - A message
 // return A - Transfer struct
-func Transfer(recipient string, amount float64, message string) transactions.Transfer {
+func Transfer(recipient base.Address, amount base.Amount, message string) transactions.Transfer {
 	return transactions.Transfer{
 		Amount:             amount,
 		Recipient:          recipient,
@@ -24,19 +23,14 @@ func Transfer(recipient string, amount float64, message string) transactions.Tra
 }
 
 // An un-prepared signature transaction struct
-// param multisigAccount - The multisig account address
+// param multisigAccount - The multisig account address, in plain or pretty form
 // param txHash - The multisig transaction hash
-// return A - SignatureT struct
-func Signature(multisigAccount, txHash string) base.SignatureT {
-	var compressedAccount string
-	if len(multisigAccount) != 0 {
-		compressedAccount = strings.ToUpper(strings.Replace(multisigAccount, "-", "", -1))
-	}
-
-	return base.SignatureT{
-		OtherHash:    struct{ Data string }{Data: txHash},
-		OtherAccount: compressedAccount,
-	}
+// return A - MultisigSignature struct
+func Signature(multisigAccount base.Address, txHash string) *transactions.MultisigSignature {
+	var tx transactions.MultisigSignature
+	tx.OtherAccount = multisigAccount
+	tx.OtherHash.Data = txHash
+	return &tx
 }
 
 // An un-prepared mosaic definition transaction object
@@ -48,26 +42,19 @@ func Mosaicdefinition() (m *transactions.MosaicDefinition) {
 }
 
 // An un-prepared mosaic supply change transaction object
-// return A - Supply struct
-func MosaicSupplyChange() base.Supply {
-	return base.Supply{
-		Mosaic:          "",
-		SupplyType:      1,
-		Delta:           0,
-		IsMultisig:      false,
-		MultisigAccount: "",
-	}
+// return A - MosaicSupply struct
+func MosaicSupplyChange() *transactions.MosaicSupply {
+	var tx transactions.MosaicSupply
+	tx.Get()
+	return &tx
 }
 
 // An un-prepared multisig aggregate modification transaction object
-// return A - MultisigAggregateModific struct
-func MultisigAggregateModification() base.MultisigAggregateModific {
-	return base.MultisigAggregateModific{
-		Modifications:   nil,
-		RelativeChange:  nil,
-		MultisigAccount: "",
-		IsMultisig:      false,
-	}
+// return A - MultisigAggregateModification struct
+func MultisigAggregateModification() *transactions.MultisigAggregateModification {
+	var tx transactions.MultisigAggregateModification
+	tx.Get()
+	return &tx
 }
 
 // An un-prepared namespace provision transaction object
@@ -82,8 +69,8 @@ func Namespaceprovision() *transactions.NamespaceProvision {
 // param remoteAccount - A remote public key
 // param mode - 1 for activating, 2 for deactivating
 // return A - ImportanceTransfer struct
-func Importancetransfer(remoteAccount string, mode int) base.ImportanceTransfer {
-	return base.ImportanceTransfer{
+func Importancetransfer(remoteAccount string, mode int) transactions.ImportanceTransfer {
+	return transactions.ImportanceTransfer{
 		RemoteAccount:   remoteAccount,
 		Mode:            mode,
 		MultisigAccount: "",
@@ -89,7 +89,7 @@ func hashFileData(data []byte, hashing Apost, isPrivate bool) string {
 }
 
 // Create an apostille object
-// param common - A common object
+// param signer - A Signer, e.g. a Common struct
 // param fileName - The file name (with extension)
 // param fileContent - The file content
 // param tags - The apostille tags
@@ -100,15 +100,13 @@ func hashFileData(data []byte, hashing Apost, isPrivate bool) string {
 // param isPrivate - True if apostille is private / transferable / updateable, false if public
 // param network - A network id
 // return - An apostille object containing apostille data and the prepared transaction ready to be sent
-func Create(common Common, fileName string, fileContent []byte, tags string, hashing Apost, isMultisig bool,
+func Create(signer Signer, fileName string, fileContent []byte, tags string, hashing Apost, isMultisig bool,
 	multisigAccount string, isPrivate bool, network int) Apostilledata {
 	var dedicatedAccount Dedicated
 	var apostilleHash string
 	if isPrivate {
-		// Create user keypair
-		kp, _ := model.KeyPairCreate(common.PrivateKey)
 		// Create the dedicated account
-		dedicatedAccount = generateAccount(common, fileName, network)
+		dedicatedAccount = generateAccount(signer, fileName, network)
 
 		// Create hash from file content and selected hashing
 		hash := hashFileData(fileContent, hashing, isPrivate)
@@ -120,7 +118,7 @@ func Create(common Common, fileName string, fileContent []byte, tags string, has
 		dataHash := hash[8:]
 
 		// Set checksum + signed hash as message
-		signed, _ := kp.Sign([]byte(dataHash))
+		signed, _ := signer.Sign([]byte(dataHash))
 		apostilleHash = checksum + utils.Bt2Hex(signed)
 
 	} else {
@@ -130,14 +128,14 @@ func Create(common Common, fileName string, fileContent []byte, tags string, has
 	}
 
 	// Create transfer transaction struct
-	transaction := TransferA(dedicatedAccount.Address, 0, apostilleHash)
+	transaction := TransferA(base.Address(dedicatedAccount.Address), 0, apostilleHash)
 	// Multisig
 	transaction.IsMultisig = isMultisig
 	transaction.MultisigAccount = multisigAccount
 	// Set message type to hexadecimal
 	transaction.MessageType = 0
 	// Prepare the transfer transaction object
-	transactionEntity, _ := transaction.Prepare(common, network)
+	transactionEntity, _ := transaction.Prepare(signer, network)
 	//fmt.Printf("%s", utils.Struc2Json(transactionEntity))
 
 	return Apostilledata{
@@ -234,26 +232,20 @@ func isSigned(hashingByte string) bool {
 }
 
 // Generate the dedicated account for a file. It will always generate the same private key for a given file name and private key
-// param common - A common object
+// param signer - A Signer, e.g. a Common struct
 // param fileName - The file name (with extension)
 // param network - A network id
 // return - An object containing address and private key of the dedicated account
-func generateAccount(common Common, fileName string, network int) Dedicated {
-	// Create user keypair
-	kp, _ := model.KeyPairCreate(common.PrivateKey)
-
+func generateAccount(signer Signer, fileName string, network int) Dedicated {
 	// Create recipient account from signed sha256 hash of new filename
 	hasher := sha256.New()
 	hasher.Write([]byte(fileName))
-	signedFilename, _ := kp.Sign([]byte(utils.Bt2Hex(hasher.Sum(nil))))
+	signedFilename, _ := signer.Sign([]byte(utils.Bt2Hex(hasher.Sum(nil))))
 
 	// Truncate signed file name to get a 32 bytes private key
 	dedicatedAccountPrivateKey := utils.FixPrivateKey(utils.Bt2Hex(signedFilename))
 
-	// Create dedicated account key pair
-	dedicatedAccountKeyPair, _ := model.KeyPairCreate(common.PrivateKey)
-
-	address, _ := model.ToAddress(dedicatedAccountKeyPair.PublicString(), network)
+	address, _ := model.ToAddress(signer.PublicKey(), network)
 	return Dedicated{
 		Address:    address,
 		PrivateKey: dedicatedAccountPrivateKey,
@@ -262,10 +254,10 @@ func generateAccount(common Common, fileName string, network int) Dedicated {
 
 // An un-prepared transfer transaction object
 // param recipient - A NEM account address
-// param amount - A number of XEM
+// param amount - An amount of micro-XEM
 // param message - A message
 // return A - Transfer struct
-func TransferA(recipient string, amount float64, message string) Transfer {
+func TransferA(recipient base.Address, amount base.Amount, message string) Transfer {
 	return Transfer{
 		Amount:             amount,
 		Recipient:          recipient,
@@ -0,0 +1,126 @@
+package transactions
+
+import (
+	"errors"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/external/crypto/sha3"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+// Compute the hash of a prepared transaction, as NIS does: the Keccak-256 of its serialized data.
+// The signature is not part of the hash, so it is known before signing and announcing.
+// param entity - A prepared transaction, or a transaction returned by NIS
+// return - The transaction hash in hexadecimal, as in NemAnnounceResult.TransactionHash
+func Hash(entity base.Transaction) (string, error) {
+	if entity == nil {
+		return "", errors.New("missing parameter !")
+	}
+	// The transfers with meta data returned by NIS are decoded as a TransactionMosaic
+	if t, ok := entity.(*base.TransactionMosaic); ok {
+		entity = transferOf(t)
+	}
+	data := utils.SerializeTransaction(entity)
+	if len(data) == 0 {
+		return "", errors.New("unknown transaction type !")
+	}
+	return hashData(data), nil
+}
+
+// Compute the hash of the inner transaction of a multisig transaction
+// For a multisig signature transaction, it is the hash of the cosigned transaction.
+// param entity - A MultiSignTransaction or a MultisigSignatureTransaction
+// return - The inner transaction hash, as in NemAnnounceResult.InnerTransactionHash
+func InnerHash(entity base.Transaction) (string, error) {
+	switch tx := entity.(type) {
+	case *base.MultiSignTransaction:
+		inner, ok := tx.OtherTrans.(base.Transaction)
+		if !ok {
+			return "", errors.New("missing inner transaction !")
+		}
+		return Hash(inner)
+	case *base.MultisigSignatureTransaction:
+		if tx.OtherHash.Data == "" {
+			return "", errors.New("missing inner transaction hash !")
+		}
+		return tx.OtherHash.Data, nil
+	}
+	return "", errors.New("not a multisig transaction !")
+}
+
+// Compute the hash of a signed transaction
+// param signed - A RequestAnnounce, e.g. from Sign or SignOffline
+// return - The transaction hash
+func AnnounceHash(signed requests.RequestAnnounce) (string, error) {
+	if signed.Data == "" || len(signed.Data)%2 != 0 || !utils.IsHexadecimal(signed.Data) {
+		return "", errors.New("data must be hexadecimal only !")
+	}
+	return hashData(utils.Hex2Bt(signed.Data)), nil
+}
+
+// Broadcast a signed transaction again, e.g. after a timeout or a crash before the result was stored
+// A transaction already known by NIS is not an error: the announce is idempotent, the same signed
+// transaction has the same hash and is included at most once.
+// param signed - A RequestAnnounce, e.g. from Sign or SignOffline
+// param endpoint - An NIS endpoint struct
+// return - The announce result, with the hashes computed locally when NIS does not report them
+func Reannounce(signed requests.RequestAnnounce, endpoint *requests.Client) (*requests.NemAnnounceResult, error) {
+	result, err := Announce(signed, endpoint)
+	if errors.Is(err, requests.ErrDuplicateTransaction) {
+		err = nil
+	}
+	if err != nil {
+		return result, err
+	}
+	tx, err := VerifyAnnounce(signed)
+	if err != nil {
+		return nil, err
+	}
+	return withHashes(result, tx)
+}
+
+// Fill the hashes of an announce result left empty by NIS
+func withHashes(result *requests.NemAnnounceResult, tx base.Transaction) (*requests.NemAnnounceResult, error) {
+	if result == nil {
+		result = &requests.NemAnnounceResult{}
+	}
+	if result.TransactionHash.Data == "" {
+		hash, err := Hash(tx)
+		if err != nil {
+			return nil, err
+		}
+		result.TransactionHash.Data = hash
+	}
+	if _, ok := tx.(*base.MultiSignTransaction); ok && result.InnerTransactionHash.Data == "" {
+		hash, err := InnerHash(tx)
+		if err != nil {
+			return nil, err
+		}
+		result.InnerTransactionHash.Data = hash
+	}
+	return result, nil
+}
+
+// Convert a TransactionMosaic to the TransferTransaction it was decoded from
+func transferOf(t *base.TransactionMosaic) *base.TransferTransaction {
+	tx := &base.TransferTransaction{
+		CommonTransaction: *t.GetCommon(),
+		Amount:            t.Amount,
+		Recipient:         t.Recipient,
+		Signature:         t.Signature,
+	}
+	if t.Message != nil {
+		tx.Message = *t.Message
+	}
+	for _, m := range t.Mosaics {
+		tx.Mosaics = append(tx.Mosaics, base.Mosaic{MosaicID: m.MosaicID, Quantity: m.Quantity})
+	}
+	return tx
+}
+
+func hashData(data []byte) string {
+	h := sha3.NewKeccak256()
+	h.Write(data)
+	return utils.Bt2Hex(h.Sum(nil))
+}
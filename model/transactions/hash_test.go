@@ -0,0 +1,130 @@
+package transactions
+
+import (
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+func TestHash(t *testing.T) {
+	account := newTestAccount(t)
+	keys, address, srv, client := account.keys, account.address, account.srv, account.client
+	tx := Transfer{Amount: base.XEM, Recipient: address}
+	prepared, err := tx.Prepare(keys, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := Hash(prepared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := Sign(keys, prepared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h, err := AnnounceHash(signed); err != nil || h != hash {
+		t.Errorf("AnnounceHash = %s, %v, want %s", h, err, hash)
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := Reannounce(signed, client)
+		if err != nil {
+			t.Fatalf("announce %d: %v", i, err)
+		}
+		if result.TransactionHash.Data != hash {
+			t.Errorf("announce %d: hash = %s, want %s", i, result.TransactionHash.Data, hash)
+		}
+	}
+	if n := len(srv.Unconfirmed()); n != 1 {
+		t.Errorf("%d unconfirmed transactions, want 1", n)
+	}
+	srv.Confirm()
+	if found, err := client.ByHash(hash); err != nil || found.Meta.Hash.Data != hash {
+		t.Errorf("ByHash(%s) = %+v, %v", hash, found, err)
+	}
+
+	// The inner hash of a multisig transaction is the hash of the inner transaction
+	tx.IsMultisig, tx.MultisigAccount = true, keys.PublicKey()
+	multisig, err := tx.Prepare(keys, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inner, err := InnerHash(multisig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := Hash(multisig.(*base.MultiSignTransaction).OtherTrans.(base.Transaction))
+	if outer, _ := Hash(multisig); inner != want || inner == outer {
+		t.Errorf("InnerHash = %s, want %s", inner, want)
+	}
+	if _, err := InnerHash(prepared); err == nil {
+		t.Error("InnerHash accepted a transfer")
+	}
+}
+
+// Known answers computed outside this package, by an independent implementation of the
+// NIS binary layout (nem.core BinarySerializer) and of the original Keccak-256 padding
+func TestHashKnownAnswers(t *testing.T) {
+	// The Keccak-256 of nothing, SHA3-256 would give a7ffc6f8bf1ed766...
+	if h := hashData(nil); h != "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470" {
+		t.Errorf("hashData(nil) = %s, not Keccak-256", h)
+	}
+
+	keys, err := NewMemorySigner(testPrivateKey, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	multisig, err := NewMemorySigner("1b3a8d8e3f2a4d0c9e7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e5d", model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const (
+		transferData = "0101000001000098c04e2305200000008aa774045175ea71bfe41cad48ed5a890cee48e26a65b9f6d6c8cdd4827c9196" +
+			"50c3000000000000d05c230528000000544243493241363755515a414b4352364e53344a574145494345494745494d3732" +
+			"47334d5657355340420f000000000000000000"
+		transferHash = "0a8d3a081bd3054cf7b31ff40b02fb06c1c0cacd54bfb863abfdcd14bbed3285"
+		multisigData = "0410000001000098c04e2305200000008aa774045175ea71bfe41cad48ed5a890cee48e26a65b9f6d6c8cdd4827c9196" +
+			"f049020000000000d05c2305740000000101000001000098c04e2305200000005a508aa892f4b9fcd7ed6e151fd5b9e13e03" +
+			"8aceedc10b9939b150bdb1ad687550c3000000000000d05c230528000000544243493241363755515a414b4352364e53344a" +
+			"574145494345494745494d373247334d5657355340420f000000000000000000"
+		multisigHash = "4c1b4176d22ebdcb5a602baaadb312e7b2effbb77a993fd29e42ab34ab861343"
+		innerHash    = "c65e1199f2be91813458119501e6aa4c62d3eddab4b1b1544d68abdb63dc3d89"
+	)
+
+	tx := Transfer{Amount: base.XEM, Recipient: "TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S"}
+	prepared, err := tx.Prepare(keys, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinTime(&prepared.(*base.TransferTransaction).CommonTransaction)
+	if data := utils.Bt2Hex(utils.SerializeTransaction(prepared)); data != transferData {
+		t.Errorf("transfer data =\n%s\nwant\n%s", data, transferData)
+	}
+	if h, err := Hash(prepared); err != nil || h != transferHash {
+		t.Errorf("Hash(transfer) = %s, %v, want %s", h, err, transferHash)
+	}
+
+	tx.IsMultisig, tx.MultisigAccount = true, multisig.PublicKey()
+	wrapper, err := tx.Prepare(keys, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := wrapper.(*base.MultiSignTransaction)
+	pinTime(&m.CommonTransaction)
+	pinTime(&m.OtherTrans.(*base.TransferTransaction).CommonTransaction)
+	if data := utils.Bt2Hex(utils.SerializeTransaction(wrapper)); data != multisigData {
+		t.Errorf("multisig data =\n%s\nwant\n%s", data, multisigData)
+	}
+	if h, err := Hash(wrapper); err != nil || h != multisigHash {
+		t.Errorf("Hash(multisig) = %s, %v, want %s", h, err, multisigHash)
+	}
+	if h, err := InnerHash(wrapper); err != nil || h != innerHash {
+		t.Errorf("InnerHash(multisig) = %s, %v, want %s", h, err, innerHash)
+	}
+	if h, err := AnnounceHash(requests.RequestAnnounce{Data: transferData}); err != nil || h != transferHash {
+		t.Errorf("AnnounceHash = %s, %v, want %s", h, err, transferHash)
+	}
+}
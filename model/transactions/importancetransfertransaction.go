@@ -0,0 +1,119 @@
+package transactions
+
+import (
+	"errors"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/extras"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+const (
+	// The importance transfer mode activating remote harvesting
+	ImportanceActivate = 1
+	// The importance transfer mode deactivating remote harvesting
+	ImportanceDeactivate = 2
+)
+
+type importancePrepare struct {
+	senderPublicKey string
+	remoteAccount   string
+	mode            int
+	due             int64
+	network         int
+}
+
+// An un-prepared importance transfer transaction.
+// RemoteAccount is the public key of the remote harvesting account.
+type ImportanceTransfer struct {
+	RemoteAccount   string `json:"remoteAccount"`
+	Mode            int    `json:"mode"`
+	IsMultisig      bool   `json:"isMultisig"`
+	MultisigAccount string `json:"multisigAccount"`
+}
+
+func (r *ImportanceTransfer) Get() {
+	r.Mode = ImportanceActivate
+	r.MultisigAccount = ""
+	r.IsMultisig = false
+}
+
+func (t *ImportanceTransfer) GetType() int {
+	return 0
+}
+
+// Prepare an importance transfer transaction struct
+// param signer - A Signer, e.g. a Common struct
+// param r - An un-prepared ImportanceTransfer method
+// param network - A network id
+// return - An [ImportanceTransferTransaction] struct
+// link http://bob.nem.ninja/docs/#importanceTransferTransaction
+func (r *ImportanceTransfer) Prepare(signer Signer, network int) (base.Transaction, error) {
+	var msc importancePrepare
+	if signer == nil || extras.IsEmpty(network) {
+		return nil, errors.New("missing parameter !")
+	}
+	if !utils.IsPublicKeyValid(r.RemoteAccount) {
+		return nil, errors.New("Invalid remote account public key!")
+	}
+	if r.Mode != ImportanceActivate && r.Mode != ImportanceDeactivate {
+		return nil, errors.New("mode must be 1 (activate) or 2 (deactivate)")
+	}
+	publicKey, err := signerPublicKey(signer, network)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsMultisig {
+		if r.MultisigAccount != "" {
+			if !utils.IsPublicKeyValid(r.MultisigAccount) {
+				return nil, errors.New("Invalid public key!")
+			}
+			msc.senderPublicKey = r.MultisigAccount
+		} else {
+			return nil, errors.New("must place a publickey of the multifirm account")
+		}
+	} else {
+		msc.senderPublicKey = publicKey
+	}
+
+	msc.remoteAccount = r.RemoteAccount
+	msc.mode = r.Mode
+
+	if network == model.Data.Testnet.ID {
+		msc.due = 60
+	} else {
+		msc.due = 24 * 60
+	}
+	msc.network = network
+
+	rt := constructImportance(msc)
+	if r.IsMultisig && r.MultisigAccount != "" {
+		return MultisigWrapper(publicKey, rt, msc.due, network), nil
+	}
+	return rt, nil
+}
+
+// Create an importance transfer transaction struct
+// param msc - An importancePrepare struct
+// return - An [ImportanceTransferTransaction] struct
+// link http://bob.nem.ninja/docs/#importanceTransferTransaction
+func constructImportance(msc importancePrepare) *base.ImportanceTransferTransaction {
+	timeStamp := utils.CreateNEMTimeStamp()
+	version := model.GetVersion(1, msc.network)
+	data := CommonPart(model.ImportanceTransfer, version, timeStamp, msc.due, msc.senderPublicKey)
+	fee := model.ImportanceTransferTransaction
+
+	return &base.ImportanceTransferTransaction{
+		CommonTransaction: base.CommonTransaction{
+			TimeStamp: data.TimeStamp,
+			Signer:    data.Signer,
+			Type:      data.Type,
+			Deadline:  data.Deadline,
+			Version:   data.Version,
+			Fee:       fee,
+		},
+		Mode:          msc.mode,
+		RemoteAccount: msc.remoteAccount,
+	}
+}
@@ -0,0 +1,47 @@
+package transactions
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+func TestImportanceTransferPrepare(t *testing.T) {
+	keys, err := NewMemorySigner(testPrivateKey, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const remote = "a1aaca6c17a24252e674d155713cdf55996ad00175be4af02a20c67b59f9fe8a"
+	valid := ImportanceTransfer{RemoteAccount: remote, Mode: ImportanceActivate}
+
+	prepared, err := valid.Prepare(keys, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := prepared.(*base.ImportanceTransferTransaction)
+	pinTime(&tx.CommonTransaction)
+	want := "01080000" + "01000098" + "c04e2305" + "20000000" + keys.PublicKey() +
+		"f049020000000000" + "d05c2305" +
+		"01000000" +
+		"20000000" + remote
+	if got := hex.EncodeToString(utils.SerializeTransaction(tx)); got != want {
+		t.Errorf("SerializeTransaction =\n%s\nwant\n%s", got, want)
+	}
+
+	for name, r := range map[string]ImportanceTransfer{
+		"missing remote account": {Mode: ImportanceActivate},
+		"invalid remote account": {RemoteAccount: "TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S", Mode: ImportanceActivate},
+		"unknown mode":           {RemoteAccount: remote, Mode: 3},
+		"multisig without key":   {RemoteAccount: remote, Mode: ImportanceDeactivate, IsMultisig: true},
+	} {
+		if _, err := r.Prepare(keys, model.Data.Testnet.ID); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+	if _, err := valid.Prepare(keys, 0); err == nil {
+		t.Error("missing network: no error")
+	}
+}
@@ -1,36 +1,46 @@
 package transactions
 
 import (
+	"errors"
 	"github.com/isarq/nem-sdk-go/base"
 	"github.com/isarq/nem-sdk-go/utils"
 )
 
 // Prepare a message struct
-// param common - A common struct
+// An encrypted message needs a Signer implementing MessageEncrypter,
+// or a hardware wallet Common struct encrypting it when signing.
+// param signer - A Signer, e.g. a Common struct
 // param tx - An un-prepared transferTransaction struct point
 // return - A prepared message struct
-func MsgPrepare(common Common, tx *Transfer) base.Message {
-	if tx.MessageType == 2 && common.PrivateKey != "" {
+func MsgPrepare(signer Signer, tx *Transfer) (base.Message, error) {
+	common, isCommon := signer.(Common)
+	encrypter, canEncrypt := signer.(MessageEncrypter)
+	if tx.MessageType == 2 && canEncrypt && (!isCommon || common.PrivateKey != "") {
+		payload, err := encrypter.EncryptMessage(tx.RecipientPublicKey, tx.Message)
+		if err != nil {
+			return base.Message{}, err
+		}
 		return base.Message{
 			Type:    2,
-			Payload: "",
-		}
-	} else if tx.MessageType == 2 && common.IsHW {
+			Payload: payload,
+		}, nil
+	} else if tx.MessageType == 2 && isCommon && common.IsHW {
 		return base.Message{
 			Type:      2,
 			Payload:   utils.Utf8ToHex(tx.Message),
 			PublicKey: tx.RecipientPublicKey,
-		}
-
+		}, nil
+	} else if tx.MessageType == 2 {
+		return base.Message{}, errors.New("the signer can not encrypt messages !")
 	} else if tx.MessageType == 0 && utils.IsHexadecimal(tx.Message) {
 		return base.Message{
 			Type:    1,
 			Payload: "fe" + tx.Message,
-		}
+		}, nil
 	} else {
 		return base.Message{
 			Type:    1,
 			Payload: utils.Utf8ToHex(tx.Message),
-		}
+		}, nil
 	}
 }
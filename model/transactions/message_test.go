@@ -0,0 +1,30 @@
+package transactions
+
+import (
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/model"
+)
+
+func TestMsgPrepare(t *testing.T) {
+	recipient, err := model.KeyPairCreate("6a858fb93e0202fa62f894e591478caa23b06f90471e7976c30fb95efda4b312")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := Transfer{Message: "secret", MessageType: 2, RecipientPublicKey: recipient.PublicString()}
+
+	for name, signer := range map[string]Signer{
+		"private key":     Common{PrivateKey: testPrivateKey},
+		"hardware wallet": Common{IsHW: true},
+	} {
+		message, err := MsgPrepare(signer, &tx)
+		if err != nil || message.Type != 2 {
+			t.Errorf("%s: MsgPrepare = %+v, %v, want an encrypted message", name, message, err)
+		}
+	}
+
+	// Never a plain message when an encrypted one is asked for
+	if message, err := MsgPrepare(Common{}, &tx); err == nil {
+		t.Errorf("Common without private key: MsgPrepare = %+v, want an error", message)
+	}
+}
@@ -34,15 +34,15 @@ type Levy struct {
 		NamespaceID string `json:"namespaceId,omitempty"`
 		Name        string `json:"name,omitempty"`
 	} `json:"mosaic,omitempty"`
-	Address string  `json:"address,omitempty"`
-	FeeType int     `json:"feeType,omitempty"`
-	Fee     float64 `json:"fee,omitempty"`
+	Address string        `json:"address,omitempty"`
+	FeeType int           `json:"feeType,omitempty"`
+	Fee     base.Quantity `json:"fee,omitempty"`
 }
 
 type mosaicPrepare struct {
 	senderPublicKey   string
 	rentalFeeSink     string
-	rentalFee         float64
+	rentalFee         base.Amount
 	namespaceParent   string
 	mosaicName        string
 	mosaicDescription string
@@ -64,16 +64,16 @@ func (r *MosaicDefinition) Get() {
 
 // Prepare a mosaic definition transaction
 // argument	r - An un-prepared mosaicDefinitionTransaction struct
-// param common - A common struct
+// param signer - A Signer, e.g. a Common struct
 // param network - A network id
 // return A [MosaicDefinitionCreationTransaction] struc ready for serialization
 // link http://bob.nem.ninja/docs/#mosaicDefinitionCreationTransaction
-func (r MosaicDefinition) Prepare(common Common, network int) *base.MosaicDefinitionCreationTransaction {
+func (r MosaicDefinition) Prepare(signer Signer, network int) *base.MosaicDefinitionCreationTransaction {
 	var msc mosaicPrepare
-	if !utils.IsPrivateKeyValid(common.PrivateKey) {
-		panic(nil)
+	publicKey, err := signerPublicKey(signer, network)
+	if err != nil {
+		panic(err)
 	}
-	kp, _ := model.KeyPairCreate(common.PrivateKey)
 	if r.IsMultisig {
 		if r.MultisigAccount != "" {
 			if !utils.IsPublicKeyValid(r.MultisigAccount) {
@@ -85,7 +85,7 @@ func (r MosaicDefinition) Prepare(common Common, network int) *base.MosaicDefini
 			panic(err)
 		}
 	} else {
-		msc.senderPublicKey = kp.PublicString()
+		msc.senderPublicKey = publicKey
 	}
 	msc.rentalFeeSink = strings.ToUpper(strings.Replace(model.Mosaic[network], "-", "", -1))
 	//fmt.Println("CreationFeeSink: ", msc.rentalFeeSink)
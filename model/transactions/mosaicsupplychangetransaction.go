@@ -0,0 +1,126 @@
+package transactions
+
+import (
+	"errors"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/extras"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+const (
+	// The supply type increasing the supply of a mosaic
+	SupplyIncrease = 1
+	// The supply type decreasing the supply of a mosaic
+	SupplyDecrease = 2
+)
+
+type supplyPrepare struct {
+	senderPublicKey string
+	mosaicId        base.MosaicID
+	supplyType      int
+	delta           uint64
+	due             int64
+	network         int
+}
+
+// An un-prepared mosaic supply change transaction.
+// Delta is expressed in whole units of the mosaic.
+type MosaicSupply struct {
+	Mosaic          base.MosaicID `json:"mosaic"`
+	SupplyType      int           `json:"supplyType"`
+	Delta           uint64        `json:"delta"`
+	IsMultisig      bool          `json:"isMultisig"`
+	MultisigAccount string        `json:"multisigAccount"`
+}
+
+func (r *MosaicSupply) Get() {
+	r.SupplyType = SupplyIncrease
+	r.MultisigAccount = ""
+	r.IsMultisig = false
+}
+
+func (t *MosaicSupply) GetType() int {
+	return 0
+}
+
+// Prepare a mosaic supply change transaction struct
+// param signer - A Signer, e.g. a Common struct
+// param r - An un-prepared MosaicSupply method
+// param network - A network id
+// return - A [MosaicSupplyChangeTransaction] struct
+// link http://bob.nem.ninja/docs/#mosaicSupplyChangeTransaction
+func (r *MosaicSupply) Prepare(signer Signer, network int) (base.Transaction, error) {
+	var msc supplyPrepare
+	if signer == nil || extras.IsEmpty(network) {
+		return nil, errors.New("missing parameter !")
+	}
+	if extras.IsEmpty(r.Mosaic.NamespaceID) || extras.IsEmpty(r.Mosaic.Name) {
+		return nil, errors.New("missing mosaic id !")
+	}
+	if r.SupplyType != SupplyIncrease && r.SupplyType != SupplyDecrease {
+		return nil, errors.New("supply type must be 1 (increase) or 2 (decrease)")
+	}
+	if r.Delta == 0 {
+		return nil, errors.New("delta must be a positive number of whole mosaic units")
+	}
+	publicKey, err := signerPublicKey(signer, network)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsMultisig {
+		if r.MultisigAccount != "" {
+			if !utils.IsPublicKeyValid(r.MultisigAccount) {
+				return nil, errors.New("Invalid public key!")
+			}
+			msc.senderPublicKey = r.MultisigAccount
+		} else {
+			return nil, errors.New("must place a publickey of the multifirm account")
+		}
+	} else {
+		msc.senderPublicKey = publicKey
+	}
+
+	msc.mosaicId = r.Mosaic
+	msc.supplyType = r.SupplyType
+	msc.delta = r.Delta
+
+	if network == model.Data.Testnet.ID {
+		msc.due = 60
+	} else {
+		msc.due = 24 * 60
+	}
+	msc.network = network
+
+	rt := constructSupply(msc)
+	if r.IsMultisig && r.MultisigAccount != "" {
+		return MultisigWrapper(publicKey, rt, msc.due, network), nil
+	}
+	return rt, nil
+}
+
+// Create a mosaic supply change transaction struct
+// param msc - A supplyPrepare struct
+// return - A [MosaicSupplyChangeTransaction] struct
+// link http://bob.nem.ninja/docs/#mosaicSupplyChangeTransaction
+func constructSupply(msc supplyPrepare) *base.MosaicSupplyChangeTransaction {
+	timeStamp := utils.CreateNEMTimeStamp()
+	version := model.GetVersion(1, msc.network)
+	data := CommonPart(model.MosaicSupply, version, timeStamp, msc.due, msc.senderPublicKey)
+	fee := model.NamespaceAndMosaicCommon
+
+	return &base.MosaicSupplyChangeTransaction{
+		CommonTransaction: base.CommonTransaction{
+			TimeStamp: data.TimeStamp,
+			Signer:    data.Signer,
+			Type:      data.Type,
+			Deadline:  data.Deadline,
+			Version:   data.Version,
+			Fee:       fee,
+		},
+		MosaicID:   msc.mosaicId,
+		SupplyType: msc.supplyType,
+		Delta:      msc.delta,
+	}
+}
@@ -0,0 +1,96 @@
+package transactions
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/com/requests/nistest"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+const testPrivateKey = "0e0f2b5d5d2a1e9ba3d4dd8f1bb6e0be5b7c8f1c29e1b9b3a55f23d44a8fa4d1"
+
+// Set the time stamp and the deadline of a prepared transaction, to compare its serialization
+func pinTime(c *base.CommonTransaction) {
+	timeStamp, deadline := int64(86200000), int64(86203600)
+	c.TimeStamp, c.Deadline = &timeStamp, &deadline
+}
+
+// The testnet account of testPrivateKey, owning 10 XEM on a fake NIS node
+type testAccount struct {
+	keys    *MemorySigner
+	address base.Address
+	srv     *nistest.Server
+	client  *requests.Client
+}
+
+// Create the test account, the fake node is closed at the end of the test
+func newTestAccount(t *testing.T) testAccount {
+	t.Helper()
+	keys, err := NewMemorySigner(testPrivateKey, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	address, err := utils.PubToAddress(keys.PublicKey(), model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := nistest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.SetAccount(requests.AccountInfo{Address: address.String(), Balance: 10 * base.XEM})
+	return testAccount{keys: keys, address: address, srv: srv, client: srv.Client()}
+}
+
+func TestMosaicSupplyPrepare(t *testing.T) {
+	keys, err := NewMemorySigner(testPrivateKey, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := MosaicSupply{Mosaic: base.MosaicID{NamespaceID: "foo", Name: "bar"}, SupplyType: SupplyDecrease, Delta: 1000}
+
+	prepared, err := valid.Prepare(keys, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := prepared.(*base.MosaicSupplyChangeTransaction)
+	pinTime(&tx.CommonTransaction)
+	want := "02400000" + "01000098" + "c04e2305" + "20000000" + keys.PublicKey() +
+		"f049020000000000" + "d05c2305" +
+		"0e000000" + "03000000666f6f" + "03000000626172" +
+		"02000000" +
+		"e803000000000000"
+	if got := hex.EncodeToString(utils.SerializeTransaction(tx)); got != want {
+		t.Errorf("SerializeTransaction =\n%s\nwant\n%s", got, want)
+	}
+
+	for name, r := range map[string]MosaicSupply{
+		"missing namespace":       {Mosaic: base.MosaicID{Name: "bar"}, SupplyType: SupplyIncrease, Delta: 1},
+		"missing name":            {Mosaic: base.MosaicID{NamespaceID: "foo"}, SupplyType: SupplyIncrease, Delta: 1},
+		"unknown supply type":     {Mosaic: valid.Mosaic, SupplyType: 3, Delta: 1},
+		"zero delta":              {Mosaic: valid.Mosaic, SupplyType: SupplyIncrease},
+		"multisig without key":    {Mosaic: valid.Mosaic, SupplyType: SupplyIncrease, Delta: 1, IsMultisig: true},
+		"multisig with a bad key": {Mosaic: valid.Mosaic, SupplyType: SupplyIncrease, Delta: 1, IsMultisig: true, MultisigAccount: "abcd"},
+	} {
+		if _, err := r.Prepare(keys, model.Data.Testnet.ID); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+	if _, err := valid.Prepare(nil, model.Data.Testnet.ID); err == nil {
+		t.Error("nil signer: no error")
+	}
+
+	// A multisig supply change is signed by the cosignatory for the multisig account
+	multisig := valid
+	multisig.IsMultisig, multisig.MultisigAccount = true, "a1aaca6c17a24252e674d155713cdf55996ad00175be4af02a20c67b59f9fe8a"
+	prepared, err = multisig.Prepare(keys, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrapper, ok := prepared.(*base.MultiSignTransaction)
+	if !ok || wrapper.Signer != keys.PublicKey() || wrapper.OtherTrans.(base.Transaction).GetCommon().Signer != multisig.MultisigAccount {
+		t.Errorf("multisig supply change = %+v", prepared)
+	}
+}
@@ -0,0 +1,244 @@
+package transactions
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/extras"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+const (
+	// The modification type adding a cosignatory
+	CosignatoryAdd = 1
+	// The modification type deleting a cosignatory
+	CosignatoryDelete = 2
+	// The maximum number of cosignatories of a multisig account
+	MaxCosignatories = 32
+)
+
+type multisigModificationPrepare struct {
+	senderPublicKey string
+	modifications   []base.ConsModif
+	relativeChange  int
+	due             int64
+	network         int
+}
+
+// An un-prepared multisig aggregate modification transaction.
+// RelativeChange is added to the current minimum of cosignatories of the account.
+type MultisigAggregateModification struct {
+	Modifications   []base.ConsModif `json:"modifications"`
+	RelativeChange  int              `json:"relativeChange"`
+	IsMultisig      bool             `json:"isMultisig"`
+	MultisigAccount string           `json:"multisigAccount"`
+}
+
+func (r *MultisigAggregateModification) Get() {
+	r.Modifications = nil
+	r.RelativeChange = 0
+	r.MultisigAccount = ""
+	r.IsMultisig = false
+}
+
+func (t *MultisigAggregateModification) GetType() int {
+	return 0
+}
+
+// Prepare a multisig aggregate modification transaction struct.
+// Without IsMultisig the signer account is converted to a multisig account, otherwise
+// the cosignatories of MultisigAccount are changed (see Verify to check them first).
+// param signer - A Signer, e.g. a Common struct
+// param r - An un-prepared MultisigAggregateModification method
+// param network - A network id
+// return - A [MultisigAggregateModificationTransaction] struct
+// link http://bob.nem.ninja/docs/#multisigAggregateModificationTransaction
+func (r *MultisigAggregateModification) Prepare(signer Signer, network int) (base.Transaction, error) {
+	var msc multisigModificationPrepare
+	if signer == nil || extras.IsEmpty(network) {
+		return nil, errors.New("missing parameter !")
+	}
+	publicKey, err := signerPublicKey(signer, network)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsMultisig {
+		if r.MultisigAccount != "" {
+			if !utils.IsPublicKeyValid(r.MultisigAccount) {
+				return nil, errors.New("Invalid public key!")
+			}
+			msc.senderPublicKey = r.MultisigAccount
+		} else {
+			return nil, errors.New("must place a publickey of the multifirm account")
+		}
+	} else {
+		msc.senderPublicKey = publicKey
+	}
+
+	if err := r.check(msc.senderPublicKey); err != nil {
+		return nil, err
+	}
+	if !r.IsMultisig {
+		// The signer is converted, it has no cosignatories yet
+		if err := r.Verify(nil, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	modifications, err := sortModifications(r.Modifications, network)
+	if err != nil {
+		return nil, err
+	}
+	msc.modifications = modifications
+	msc.relativeChange = r.RelativeChange
+
+	if network == model.Data.Testnet.ID {
+		msc.due = 60
+	} else {
+		msc.due = 24 * 60
+	}
+	msc.network = network
+
+	rt := constructMultisigModification(msc)
+	if r.IsMultisig && r.MultisigAccount != "" {
+		return MultisigWrapper(publicKey, rt, msc.due, network), nil
+	}
+	return rt, nil
+}
+
+// Verify the modifications against the current state of the multisig account
+// param cosignatories - The public keys of the current cosignatories
+// param minCosignatories - The current minimum of cosignatories
+// return - An error if NIS would reject the modifications
+func (r *MultisigAggregateModification) Verify(cosignatories []string, minCosignatories int) error {
+	current := make(map[string]bool)
+	for _, c := range cosignatories {
+		current[strings.ToLower(c)] = true
+	}
+	count := len(current)
+	for _, m := range r.Modifications {
+		key := strings.ToLower(m.CosignatoryAccount)
+		switch m.ModificationType {
+		case CosignatoryAdd:
+			if current[key] {
+				return errors.New("cosignatory " + m.CosignatoryAccount + " is already a cosignatory")
+			}
+			count++
+		case CosignatoryDelete:
+			if !current[key] {
+				return errors.New("cosignatory " + m.CosignatoryAccount + " is not a cosignatory")
+			}
+			count--
+		}
+	}
+	if count > MaxCosignatories {
+		return errors.New("too many cosignatories")
+	}
+	if len(cosignatories) == 0 && count == 0 {
+		return errors.New("an account must have at least one cosignatory to be converted to multisig")
+	}
+	min := minCosignatories + r.RelativeChange
+	if min < 0 || min > count {
+		return errors.New("minimum cosignatories out of range")
+	}
+	return nil
+}
+
+// Verify the modifications against the multisig account data returned by NIS
+// param account - The multisig account data (see requests.Client.AccountData)
+// return - An error if NIS would reject the modifications
+func (r *MultisigAggregateModification) VerifyAccount(account requests.AccountMetaDataPair) error {
+	var cosignatories []string
+	for _, c := range account.Meta.Cosignatories {
+		cosignatories = append(cosignatories, c.PublicKey)
+	}
+	return r.Verify(cosignatories, account.Account.MultisigInfo.MinCosignatories)
+}
+
+// check the rules that do not depend on the state of the account
+func (r *MultisigAggregateModification) check(senderPublicKey string) error {
+	if len(r.Modifications) == 0 && r.RelativeChange == 0 {
+		return errors.New("missing modifications !")
+	}
+	seen := make(map[string]bool)
+	deletions := 0
+	for _, m := range r.Modifications {
+		if m.ModificationType != CosignatoryAdd && m.ModificationType != CosignatoryDelete {
+			return errors.New("modification type must be 1 (add) or 2 (delete)")
+		}
+		if !utils.IsPublicKeyValid(m.CosignatoryAccount) {
+			return errors.New("Invalid cosignatory public key!")
+		}
+		key := strings.ToLower(m.CosignatoryAccount)
+		if seen[key] {
+			return errors.New("cosignatory " + m.CosignatoryAccount + " is duplicated")
+		}
+		seen[key] = true
+		if key == strings.ToLower(senderPublicKey) {
+			return errors.New("a multisig account cannot be its own cosignatory")
+		}
+		if m.ModificationType == CosignatoryDelete {
+			deletions++
+		}
+	}
+	if deletions > 1 {
+		return errors.New("only one cosignatory can be deleted per transaction")
+	}
+	return nil
+}
+
+// Sort modifications the way NIS does: by type, then by cosignatory address
+// param modifications - A slice of modifications
+// param network - A network id
+// return - The sorted modifications
+func sortModifications(modifications []base.ConsModif, network int) ([]base.ConsModif, error) {
+	addresses := make(map[string]string)
+	for _, m := range modifications {
+		address, err := model.ToAddress(m.CosignatoryAccount, network)
+		if err != nil {
+			return nil, err
+		}
+		addresses[m.CosignatoryAccount] = address
+	}
+	sorted := make([]base.ConsModif, len(modifications))
+	copy(sorted, modifications)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].ModificationType != sorted[j].ModificationType {
+			return sorted[i].ModificationType < sorted[j].ModificationType
+		}
+		return addresses[sorted[i].CosignatoryAccount] < addresses[sorted[j].CosignatoryAccount]
+	})
+	return sorted, nil
+}
+
+// Create a multisig aggregate modification transaction struct
+// param msc - A multisigModificationPrepare struct
+// return - A [MultisigAggregateModificationTransaction] struct
+// link http://bob.nem.ninja/docs/#multisigAggregateModificationTransaction
+func constructMultisigModification(msc multisigModificationPrepare) *base.MultisigAggregateModificationTransaction {
+	timeStamp := utils.CreateNEMTimeStamp()
+	version := model.GetVersion(2, msc.network)
+	data := CommonPart(model.MultisigModification, version, timeStamp, msc.due, msc.senderPublicKey)
+	fee := model.MultisigAggregateModificationTransaction
+
+	custom := base.MultisigAggregateModificationTransaction{
+		CommonTransaction: base.CommonTransaction{
+			TimeStamp: data.TimeStamp,
+			Signer:    data.Signer,
+			Type:      data.Type,
+			Deadline:  data.Deadline,
+			Version:   data.Version,
+			Fee:       fee,
+		},
+		Modifications: msc.modifications,
+	}
+	// Without change the minimum cosignatories are omitted, as NanoWallet does
+	if msc.relativeChange != 0 {
+		custom.MinCosignatories = &base.MinCosignatories{RelativeChange: msc.relativeChange}
+	}
+	return &custom
+}
@@ -0,0 +1,181 @@
+package transactions
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+// The public keys of test cosignatories, from the private keys 11..11, 22..22 and so on
+func testCosignatories(t *testing.T, n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		signer, err := NewMemorySigner(strings.Repeat(string(rune('1'+i)), 64), model.Data.Testnet.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = signer.PublicKey()
+	}
+	return keys
+}
+
+func TestMultisigModificationPrepare(t *testing.T) {
+	keys, err := NewMemorySigner(testPrivateKey, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cosignatories := testCosignatories(t, 2)
+	r := MultisigAggregateModification{Modifications: []base.ConsModif{
+		{ModificationType: CosignatoryAdd, CosignatoryAccount: cosignatories[0]},
+		{ModificationType: CosignatoryAdd, CosignatoryAccount: cosignatories[1]},
+	}}
+
+	// Without change the minimum cosignatories are omitted
+	prepared, err := r.Prepare(keys, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := prepared.(*base.MultisigAggregateModificationTransaction)
+	if tx.MinCosignatories != nil {
+		t.Errorf("MinCosignatories = %+v, want nil", tx.MinCosignatories)
+	}
+	if got := hex.EncodeToString(utils.SerializeTransaction(tx)); !strings.HasSuffix(got, tx.Modifications[1].CosignatoryAccount+"00000000") {
+		t.Errorf("SerializeTransaction = %s, want no minimum cosignatories", got)
+	}
+
+	r.RelativeChange = 1
+	prepared, err = r.Prepare(keys, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx = prepared.(*base.MultisigAggregateModificationTransaction)
+	if tx.MinCosignatories == nil || tx.MinCosignatories.RelativeChange != 1 {
+		t.Errorf("MinCosignatories = %+v, want 1", tx.MinCosignatories)
+	}
+	if got := hex.EncodeToString(utils.SerializeTransaction(tx)); !strings.HasSuffix(got, "0400000001000000") {
+		t.Errorf("SerializeTransaction = %s, want a relative change of 1", got)
+	}
+}
+
+func TestMultisigModificationCheck(t *testing.T) {
+	keys, err := NewMemorySigner(testPrivateKey, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := testCosignatories(t, 2)
+	add := func(key string) base.ConsModif {
+		return base.ConsModif{ModificationType: CosignatoryAdd, CosignatoryAccount: key}
+	}
+	del := func(key string) base.ConsModif {
+		return base.ConsModif{ModificationType: CosignatoryDelete, CosignatoryAccount: key}
+	}
+
+	for name, mods := range map[string][]base.ConsModif{
+		"no modifications":      nil,
+		"duplicate cosignatory": {add(c[0]), add(strings.ToUpper(c[0]))},
+		"added and deleted":     {add(c[0]), del(c[0])},
+		"own cosignatory":       {add(keys.PublicKey())},
+		"two deletions":         {del(c[0]), del(c[1])},
+		"unknown type":          {{ModificationType: 3, CosignatoryAccount: c[0]}},
+		"invalid key":           {add("TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S")},
+	} {
+		r := MultisigAggregateModification{Modifications: mods}
+		if err := r.check(keys.PublicKey()); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+		if _, err := r.Prepare(keys, model.Data.Testnet.ID); err == nil {
+			t.Errorf("%s: Prepare without error", name)
+		}
+	}
+	r := MultisigAggregateModification{Modifications: []base.ConsModif{add(c[0]), del(c[1])}}
+	if err := r.check(keys.PublicKey()); err != nil {
+		t.Errorf("one addition and one deletion: %v", err)
+	}
+}
+
+func TestMultisigModificationVerify(t *testing.T) {
+	c := testCosignatories(t, 3)
+	add := func(key string) base.ConsModif {
+		return base.ConsModif{ModificationType: CosignatoryAdd, CosignatoryAccount: key}
+	}
+	del := func(key string) base.ConsModif {
+		return base.ConsModif{ModificationType: CosignatoryDelete, CosignatoryAccount: key}
+	}
+	members := c[:2]
+
+	for _, tt := range []struct {
+		name          string
+		mods          []base.ConsModif
+		change        int
+		cosignatories []string
+		min           int
+		ok            bool
+	}{
+		{"add a cosignatory", []base.ConsModif{add(c[2])}, 1, members, 2, true},
+		{"remove a cosignatory", []base.ConsModif{del(c[1])}, -1, members, 2, true},
+		{"add a member", []base.ConsModif{add(strings.ToUpper(c[0]))}, 0, members, 1, false},
+		{"remove a non-member", []base.ConsModif{del(c[2])}, 0, members, 1, false},
+		{"min above the cosignatories", []base.ConsModif{del(c[1])}, 0, members, 2, false},
+		{"min below 0", nil, -2, members, 1, false},
+		{"min at 0", nil, -1, members, 1, true},
+		{"conversion", []base.ConsModif{add(c[0])}, 1, nil, 0, true},
+		{"conversion without cosignatory", nil, 0, nil, 0, false},
+	} {
+		r := MultisigAggregateModification{Modifications: tt.mods, RelativeChange: tt.change}
+		if err := r.Verify(tt.cosignatories, tt.min); (err == nil) != tt.ok {
+			t.Errorf("%s: err = %v", tt.name, err)
+		}
+	}
+
+	many := make([]string, MaxCosignatories)
+	for i := range many {
+		many[i] = strings.Repeat("0", 62) + hex.EncodeToString([]byte{byte(i)})
+	}
+	r := MultisigAggregateModification{Modifications: []base.ConsModif{add(c[0])}}
+	if err := r.Verify(many, 1); err == nil {
+		t.Error("too many cosignatories: no error")
+	}
+}
+
+func TestSortModifications(t *testing.T) {
+	c := testCosignatories(t, 4)
+	address := make(map[string]string)
+	for _, key := range c {
+		a, err := model.ToAddress(key, model.Data.Testnet.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		address[key] = a
+	}
+	var mods []base.ConsModif
+	for i := len(c) - 1; i >= 0; i-- {
+		mods = append(mods, base.ConsModif{ModificationType: CosignatoryDelete - i%2, CosignatoryAccount: c[i]})
+	}
+
+	sorted, err := sortModifications(mods, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sorted) != len(mods) {
+		t.Fatalf("%d modifications, want %d", len(sorted), len(mods))
+	}
+	for i := 1; i < len(sorted); i++ {
+		prev, m := sorted[i-1], sorted[i]
+		if prev.ModificationType > m.ModificationType ||
+			prev.ModificationType == m.ModificationType && address[prev.CosignatoryAccount] > address[m.CosignatoryAccount] {
+			t.Errorf("modification %d (%d %s) before %d (%d %s)", i-1, prev.ModificationType,
+				address[prev.CosignatoryAccount], i, m.ModificationType, address[m.CosignatoryAccount])
+		}
+	}
+	// The input is not modified
+	if mods[0].CosignatoryAccount != c[len(c)-1] {
+		t.Error("sortModifications modified its input")
+	}
+	if _, err := sortModifications([]base.ConsModif{{ModificationType: CosignatoryAdd, CosignatoryAccount: "zz"}}, model.Data.Testnet.ID); err == nil {
+		t.Error("invalid key: no error")
+	}
+}
@@ -0,0 +1,171 @@
+package transactions
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/extras"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+type signaturePrepare struct {
+	senderPublicKey string
+	otherHash       string
+	otherAccount    string
+	due             int64
+	network         int
+}
+
+// An un-prepared multisig signature transaction.
+// OtherHash is the hash of the inner transaction to cosign and OtherAccount the multisig account address.
+type MultisigSignature struct {
+	OtherHash struct {
+		Data string `json:"data"`
+	} `json:"otherHash"`
+	OtherAccount base.Address `json:"otherAccount"`
+}
+
+func (t *MultisigSignature) GetType() int {
+	return 0
+}
+
+// Prepare a multisig signature transaction struct
+// param signer - A Signer, e.g. a Common struct
+// param r - An un-prepared MultisigSignature method
+// param network - A network id
+// return - A [MultisigSignatureTransaction] struct
+// link http://bob.nem.ninja/docs/#multisigSignatureTransaction
+func (r *MultisigSignature) Prepare(signer Signer, network int) (base.Transaction, error) {
+	var msc signaturePrepare
+	if signer == nil || extras.IsEmpty(network) {
+		return nil, errors.New("missing parameter !")
+	}
+	if len(r.OtherHash.Data) != 64 || !utils.IsHexadecimal(r.OtherHash.Data) {
+		return nil, errors.New("Invalid transaction hash!")
+	}
+	otherAccount, err := base.ParseAddress(string(r.OtherAccount))
+	if err != nil {
+		return nil, err
+	}
+	if !otherAccount.IsFromNetwork(network) {
+		return nil, errors.New("Multisig account address is not from the transaction network !")
+	}
+	publicKey, err := signerPublicKey(signer, network)
+	if err != nil {
+		return nil, err
+	}
+
+	msc.senderPublicKey = publicKey
+	msc.otherHash = r.OtherHash.Data
+	msc.otherAccount = otherAccount.String()
+
+	if network == model.Data.Testnet.ID {
+		msc.due = 60
+	} else {
+		msc.due = 24 * 60
+	}
+	msc.network = network
+
+	return constructSignature(msc), nil
+}
+
+// Create a multisig signature transaction struct
+// param msc - A signaturePrepare struct
+// return - A [MultisigSignatureTransaction] struct
+// link http://bob.nem.ninja/docs/#multisigSignatureTransaction
+func constructSignature(msc signaturePrepare) *base.MultisigSignatureTransaction {
+	timeStamp := utils.CreateNEMTimeStamp()
+	version := model.GetVersion(1, msc.network)
+	data := CommonPart(model.MultiSignSignature, version, timeStamp, msc.due, msc.senderPublicKey)
+	fee := model.SignatureTransaction
+
+	custom := base.MultisigSignatureTransaction{
+		CommonTransaction: base.CommonTransaction{
+			TimeStamp: data.TimeStamp,
+			Signer:    data.Signer,
+			Type:      data.Type,
+			Deadline:  data.Deadline,
+			Version:   data.Version,
+			Fee:       fee,
+		},
+		OtherAccount: msc.otherAccount,
+	}
+	custom.OtherHash.Data = msc.otherHash
+	return &custom
+}
+
+// Gets the multisig transactions of an account still waiting for the signature of a cosignatory
+// param endpoint - An NIS endpoint struct
+// param address - The address of the cosignatory or of the multisig account
+// param cosignatoryPublicKey - The public key of the cosignatory
+// return - An slice of pending [UnconfirmedTransactionMetaDataPair] struct
+func PendingSignatures(endpoint *requests.Client, address base.Address, cosignatoryPublicKey string) ([]requests.UnconfirmedTransactionMetaDataPair, error) {
+	if extras.IsEmpty(endpoint) || address == "" || !utils.IsPublicKeyValid(cosignatoryPublicKey) {
+		return nil, errors.New("Missing parameter !")
+	}
+	unconfirmed, err := endpoint.UnconfirmedTransactionsMeta(address)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []requests.UnconfirmedTransactionMetaDataPair
+	for _, u := range unconfirmed {
+		tx, ok := u.Transaction.(*base.MultiSignTransaction)
+		if !ok || u.Meta.Data == "" {
+			continue
+		}
+		if hasSigned(tx, cosignatoryPublicKey) {
+			continue
+		}
+		pending = append(pending, u)
+	}
+	return pending, nil
+}
+
+// Check if a cosignatory initiated or already signed a multisig transaction
+func hasSigned(tx *base.MultiSignTransaction, publicKey string) bool {
+	if strings.EqualFold(tx.Signer, publicKey) {
+		return true
+	}
+	for _, s := range tx.Signatures {
+		if strings.EqualFold(s.Signer, publicKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// Cosign a pending multisig transaction and broadcast the signature to the network
+// param signer - The Signer of the cosignatory
+// param pending - A pending multisig transaction (see PendingSignatures)
+// param endpoint - An NIS endpoint struct
+// param network - A network id
+// return - An announce transaction promise of the com.requests service
+func Cosign(signer Signer, pending requests.UnconfirmedTransactionMetaDataPair, endpoint *requests.Client,
+	network int) (*requests.NemAnnounceResult, error) {
+	tx, ok := pending.Transaction.(*base.MultiSignTransaction)
+	if !ok {
+		return nil, errors.New("pending transaction is not a multisig transaction")
+	}
+	inner, ok := tx.OtherTrans.(base.Transaction)
+	if !ok {
+		return nil, errors.New("pending transaction has no inner transaction")
+	}
+	multisigAccount, err := model.ToAddress(inner.GetCommon().Signer, network)
+	if err != nil {
+		return nil, err
+	}
+
+	var signature MultisigSignature
+	signature.OtherHash.Data = pending.Meta.Data
+	signature.OtherAccount = base.Address(multisigAccount)
+
+	entity, err := signature.Prepare(signer, network)
+	if err != nil {
+		return nil, err
+	}
+	return Send(signer, entity, endpoint)
+}
@@ -0,0 +1,143 @@
+package transactions
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/com/requests/nistest"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+func TestMultisigSignaturePrepare(t *testing.T) {
+	keys, err := NewMemorySigner(testPrivateKey, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const (
+		hash    = "e1f7b2e1dd6e1f5ad8a4c34e1bc1ba4b41def2b8d44b6b0e5bd7d82a3b6a6a54"
+		account = "TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S"
+	)
+	var valid MultisigSignature
+	valid.OtherHash.Data = hash
+	valid.OtherAccount = "TBCI2A-67UQZA-KCR6NS-4JWAEI-CEIGEI-M72G3M-VW5S"
+
+	prepared, err := valid.Prepare(keys, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := prepared.(*base.MultisigSignatureTransaction)
+	pinTime(&tx.CommonTransaction)
+	want := "02100000" + "01000098" + "c04e2305" + "20000000" + keys.PublicKey() +
+		"f049020000000000" + "d05c2305" +
+		"24000000" + "20000000" + hash +
+		"28000000" + hex.EncodeToString([]byte(account))
+	if got := hex.EncodeToString(utils.SerializeTransaction(tx)); got != want {
+		t.Errorf("SerializeTransaction =\n%s\nwant\n%s", got, want)
+	}
+
+	for name, mutate := range map[string]func(*MultisigSignature){
+		"short hash":      func(r *MultisigSignature) { r.OtherHash.Data = hash[:62] },
+		"hash not in hex": func(r *MultisigSignature) { r.OtherHash.Data = "zz" + hash[2:] },
+		"missing account": func(r *MultisigSignature) { r.OtherAccount = "" },
+		"short account":   func(r *MultisigSignature) { r.OtherAccount = base.Address(account[:39]) },
+		"bad checksum":    func(r *MultisigSignature) { r.OtherAccount = base.Address(account[:39] + "T") },
+		"mainnet account": func(r *MultisigSignature) { r.OtherAccount = "NALICELGU3IVY4DPJKHYLSSVYFFWYS5QPLYEZDJJ" },
+	} {
+		r := valid
+		mutate(&r)
+		if _, err := r.Prepare(keys, model.Data.Testnet.ID); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+func TestPendingSignatures(t *testing.T) {
+	signers := make(map[string]*MemorySigner)
+	for name, key := range map[string]string{
+		"multisig":  "1b3a8d8e3f2a4d0c9e7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e5d",
+		"initiator": testPrivateKey,
+		"cosigner":  "2c4b9e9f4a3b5e1dafac7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e",
+		"other":     "3d5caf0a5b4c6f2ebabd8c7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f",
+	} {
+		s, err := NewMemorySigner(key, model.Data.Testnet.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signers[name] = s
+	}
+	address := func(name string) base.Address {
+		a, err := utils.PubToAddress(signers[name].PublicKey(), model.Data.Testnet.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return a
+	}
+
+	srv := nistest.NewServer()
+	defer srv.Close()
+	for name := range signers {
+		srv.SetAccount(requests.AccountInfo{Address: address(name).String(), Balance: 100 * base.XEM})
+	}
+	client := srv.Client()
+
+	// Two multisig transfers from the initiator, and a transfer of the multisig account itself
+	send := func(signer Signer, tx Transfer) {
+		t.Helper()
+		prepared, err := tx.Prepare(signer, model.Data.Testnet.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Send(signer, prepared, client); err != nil {
+			t.Fatal(err)
+		}
+	}
+	multisig := signers["multisig"].PublicKey()
+	send(signers["initiator"], Transfer{Amount: base.XEM, Recipient: address("other"), IsMultisig: true, MultisigAccount: multisig})
+	send(signers["initiator"], Transfer{Amount: 2 * base.XEM, Recipient: address("other"), IsMultisig: true, MultisigAccount: multisig})
+	send(signers["multisig"], Transfer{Amount: 3 * base.XEM, Recipient: address("other")})
+
+	pending, err := PendingSignatures(client, address("multisig"), signers["cosigner"].PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("%d pending transactions, want the 2 multisig transactions", len(pending))
+	}
+
+	// The cosigner signs the second one
+	var second requests.UnconfirmedTransactionMetaDataPair
+	for _, p := range pending {
+		inner := p.Transaction.(*base.MultiSignTransaction).OtherTrans.(*base.TransferTransaction)
+		if inner.Amount == 2*base.XEM {
+			second = p
+		}
+	}
+	if _, err := Cosign(signers["cosigner"], second, client, model.Data.Testnet.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]int{"cosigner": 1, "initiator": 0, "other": 2} {
+		pending, err := PendingSignatures(client, address("multisig"), signers[name].PublicKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pending) != want {
+			t.Errorf("%s: %d pending transactions, want %d", name, len(pending), want)
+		}
+		for _, p := range pending {
+			if p.Meta.Data == second.Meta.Data && name == "cosigner" {
+				t.Error("the cosigned transaction is still pending for the cosigner")
+			}
+		}
+	}
+
+	if _, err := PendingSignatures(client, address("multisig"), "not a key"); err == nil {
+		t.Error("invalid cosignatory public key: no error")
+	}
+	if _, err := Cosign(signers["cosigner"], requests.UnconfirmedTransactionMetaDataPair{}, client, model.Data.Testnet.ID); err == nil {
+		t.Error("Cosign of a non multisig transaction: no error")
+	}
+}
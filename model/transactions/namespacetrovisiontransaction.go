@@ -13,7 +13,7 @@ import (
 type nsPrepare struct {
 	senderPublicKey string
 	rentalFeeSink   string
-	rentalFee       float64
+	rentalFee       base.Amount
 	namespaceParent string
 	namespaceName   string
 	due             int64
@@ -39,18 +39,21 @@ func (t *NamespaceProvision) GetType() int {
 }
 
 // Prepare a namespace provision transaction object
-// param common - A common struct
+// param signer - A Signer, e.g. a Common struct
 // param r - An un-prepared namespaceProvisionTransaction method
 // param network - A network id
 // return - A [ProvisionNamespaceTransaction] struct
 // link {http://bob.nem.ninja/docs/#provisionNamespaceTransaction}
-func (r *NamespaceProvision) Prepare(common Common, network int) base.Transaction {
+func (r *NamespaceProvision) Prepare(signer Signer, network int) base.Transaction {
 	var msc nsPrepare
-	if extras.IsEmpty(common) || extras.IsEmpty(network) {
+	if signer == nil || extras.IsEmpty(network) {
 		err := errors.New("missing parameter !")
 		panic(err)
 	}
-	kp, _ := model.KeyPairCreate(common.PrivateKey)
+	publicKey, err := signerPublicKey(signer, network)
+	if err != nil {
+		panic(err)
+	}
 	if r.IsMultisig {
 		if r.MultisigAccount != "" {
 			if !utils.IsPublicKeyValid(r.MultisigAccount) {
@@ -62,7 +65,7 @@ func (r *NamespaceProvision) Prepare(common Common, network int) base.Transactio
 			panic(err)
 		}
 	} else {
-		msc.senderPublicKey = kp.PublicString()
+		msc.senderPublicKey = publicKey
 	}
 
 	msc.rentalFeeSink = strings.ToUpper(strings.Replace(model.Namespace[network], "-", "", -1))
@@ -87,7 +90,7 @@ func (r *NamespaceProvision) Prepare(common Common, network int) base.Transactio
 
 	rt := construct(msc)
 	if r.IsMultisig && r.MultisigAccount != "" {
-		return MultisigWrapper(kp.PublicString(), rt, msc.due, network)
+		return MultisigWrapper(publicKey, rt, msc.due, network)
 	}
 	return rt
 }
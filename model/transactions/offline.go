@@ -0,0 +1,227 @@
+package transactions
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+// The longest deadline accepted by NIS after the time stamp of a transaction
+const MaxDeadline = 24 * time.Hour
+
+var ErrNoPrivateKey = errors.New("the signer has no private key !")
+
+// A PublicKeySigner prepares transactions with a public key only, e.g. on an online
+// machine while the private key stays on an air-gapped one. It can not sign.
+type PublicKeySigner struct {
+	publicKey string
+	network   int
+}
+
+// Create a signer preparing the transactions of a public key
+// param publicKey - The public key of the account
+// param network - A network id, 0 for any network
+// return - A PublicKeySigner point
+func NewPublicKeySigner(publicKey string, network int) (*PublicKeySigner, error) {
+	if !utils.IsPublicKeyValid(publicKey) {
+		return nil, errors.New("Invalid public key !")
+	}
+	return &PublicKeySigner{publicKey: strings.ToLower(publicKey), network: network}, nil
+}
+
+func (s *PublicKeySigner) PublicKey() string {
+	return s.publicKey
+}
+
+func (s *PublicKeySigner) Network() int {
+	return s.network
+}
+
+// Sign always fails with ErrNoPrivateKey
+func (s *PublicKeySigner) Sign(payload []byte) ([]byte, error) {
+	return nil, ErrNoPrivateKey
+}
+
+// Set the deadline of a prepared transaction, and of its inner transaction for a multisig
+// The deadline of a transaction signed offline must leave time to carry it back online.
+// param entity - A prepared transaction
+// param deadline - The deadline, at most MaxDeadline after the time stamp
+func SetDeadline(entity base.Transaction, deadline time.Time) error {
+	common := entity.GetCommon()
+	due := utils.ToNEMTimeStamp(deadline)
+	if common.TimeStamp == nil || due <= *common.TimeStamp {
+		return errors.New("deadline must be after the time stamp of the transaction !")
+	}
+	if time.Duration(due-*common.TimeStamp)*time.Second > MaxDeadline {
+		return errors.New("deadline must be at most 24 hours after the time stamp of the transaction !")
+	}
+	common.Deadline = &due
+	if m, ok := entity.(*base.MultiSignTransaction); ok {
+		if inner, ok := m.OtherTrans.(base.Transaction); ok {
+			inner.GetCommon().Deadline = &due
+		}
+	}
+	return nil
+}
+
+// An UnsignedTransaction is a prepared transaction exported to be signed elsewhere
+type UnsignedTransaction struct {
+	// Data is the serialized transaction in hexadecimal, the payload to sign
+	Data string `json:"data"`
+	// Signer is the public key expected to sign the transaction
+	Signer string `json:"signer"`
+	// Type is the transaction type
+	Type int `json:"type"`
+	// Network is the network id of the transaction
+	Network int `json:"network"`
+	// Deadline is the NEM time stamp after which the transaction is rejected
+	Deadline int64 `json:"deadline"`
+	// Transaction is the transaction in readable form, for review only: Data is what gets signed,
+	// ParseUnsigned decodes it again from Data
+	Transaction json.RawMessage `json:"transaction,omitempty"`
+}
+
+// Export a prepared transaction to sign it elsewhere
+// param entity - A prepared transaction
+// return - An UnsignedTransaction point
+func Export(entity base.Transaction) (*UnsignedTransaction, error) {
+	if entity == nil {
+		return nil, errors.New("missing parameter !")
+	}
+	readable, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	u := &UnsignedTransaction{Data: utils.Bt2Hex(utils.SerializeTransaction(entity)), Transaction: readable}
+	if _, err := u.Decode(); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Parse an unsigned transaction exported as JSON or as hexadecimal
+// param data - The JSON of an UnsignedTransaction or the serialized transaction in hexadecimal
+// return - An UnsignedTransaction point, its Transaction is decoded from Data
+func ParseUnsigned(data []byte) (*UnsignedTransaction, error) {
+	data = bytes.TrimSpace(data)
+	u := &UnsignedTransaction{}
+	if len(data) > 0 && data[0] == '{' {
+		if err := json.Unmarshal(data, u); err != nil {
+			return nil, err
+		}
+	} else {
+		u.Data = string(data)
+	}
+	tx, err := u.Decode()
+	if err != nil {
+		return nil, err
+	}
+	// The readable form of the file is not trusted, it could show another transaction than Data
+	if u.Transaction, err = json.Marshal(tx); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Gets the serialized transaction in hexadecimal
+func (u *UnsignedTransaction) Hex() string {
+	return u.Data
+}
+
+// Encode the unsigned transaction as indented JSON
+func (u *UnsignedTransaction) JSON() ([]byte, error) {
+	return json.MarshalIndent(u, "", "  ")
+}
+
+// Deserialize the transaction, filling Signer, Type, Network and Deadline from Data
+// An error is returned when these fields do not match Data.
+// return - The transaction to sign
+func (u *UnsignedTransaction) Decode() (base.Transaction, error) {
+	if u.Data == "" || len(u.Data)%2 != 0 || !utils.IsHexadecimal(u.Data) {
+		return nil, errors.New("data must be hexadecimal only !")
+	}
+	tx, err := utils.DeserializeTransaction(utils.Hex2Bt(u.Data))
+	if err != nil {
+		return nil, err
+	}
+	common := tx.GetCommon()
+	network := int(int8(uint32(common.Version) >> 24))
+	var deadline int64
+	if common.Deadline != nil {
+		deadline = *common.Deadline
+	}
+	if u.Signer != "" && !strings.EqualFold(u.Signer, common.Signer) ||
+		u.Type != 0 && u.Type != common.Type ||
+		u.Network != 0 && u.Network != network ||
+		u.Deadline != 0 && u.Deadline != deadline {
+		return nil, errors.New("unsigned transaction fields do not match its data !")
+	}
+	u.Signer, u.Type, u.Network, u.Deadline = common.Signer, common.Type, network, deadline
+	return tx, nil
+}
+
+// Sign an unsigned transaction, e.g. on an air-gapped machine
+// param u - An UnsignedTransaction point
+// param signer - The Signer of the transaction signer
+// return - The RequestAnnounce to announce later, see Announce
+func SignOffline(u *UnsignedTransaction, signer Signer) (requests.RequestAnnounce, error) {
+	if u == nil || signer == nil {
+		return requests.RequestAnnounce{}, errors.New("missing parameter !")
+	}
+	if _, err := u.Decode(); err != nil {
+		return requests.RequestAnnounce{}, err
+	}
+	if !strings.EqualFold(signer.PublicKey(), u.Signer) {
+		return requests.RequestAnnounce{}, errors.New("The transaction signer does not match the signer public key !")
+	}
+	if n := signer.Network(); n != 0 && n != u.Network {
+		return requests.RequestAnnounce{}, errors.New("signer network does not match the transaction network !")
+	}
+	signature, err := signer.Sign(utils.Hex2Bt(u.Data))
+	if err != nil {
+		return requests.RequestAnnounce{}, err
+	}
+	return requests.RequestAnnounce{Data: u.Data, Signature: utils.Bt2Hex(signature)}, nil
+}
+
+// Verify the signature of a signed transaction
+// param signed - A RequestAnnounce, e.g. from SignOffline
+// return - The signed transaction
+func VerifyAnnounce(signed requests.RequestAnnounce) (base.Transaction, error) {
+	tx, err := (&UnsignedTransaction{Data: signed.Data}).Decode()
+	if err != nil {
+		return nil, err
+	}
+	if len(signed.Signature) != 128 || !utils.IsHexadecimal(signed.Signature) {
+		return nil, errors.New("signature must be 64 bytes in hexadecimal !")
+	}
+	if !model.Verify(utils.Hex2Bt(tx.GetCommon().Signer), utils.Hex2Bt(signed.Data), utils.Hex2Bt(signed.Signature)) {
+		return nil, errors.New("invalid signature !")
+	}
+	return tx, nil
+}
+
+// Verify and broadcast a transaction signed offline
+// param signed - A RequestAnnounce, e.g. from SignOffline
+// param endpoint - An NIS endpoint struct
+// return - An announce transaction promise of the com.requests service
+func Announce(signed requests.RequestAnnounce, endpoint *requests.Client) (*requests.NemAnnounceResult, error) {
+	if endpoint == nil {
+		return nil, errors.New("missing parameter !")
+	}
+	tx, err := VerifyAnnounce(signed)
+	if err != nil {
+		return nil, err
+	}
+	if deadline := tx.GetCommon().Deadline; deadline != nil && *deadline < utils.CreateNEMTimeStamp() {
+		return nil, errors.New("the transaction deadline has expired !")
+	}
+	return endpoint.Announce(signed)
+}
@@ -0,0 +1,86 @@
+package transactions
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/model"
+)
+
+func TestOfflineSigning(t *testing.T) {
+	account := newTestAccount(t)
+	keys, address := account.keys, account.address
+
+	// Online: prepare with the public key only and export
+	watch, err := NewPublicKeySigner(keys.PublicKey(), model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := Transfer{Amount: base.XEM, Recipient: address, Message: "offline"}
+	prepared, err := tx.Prepare(watch, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SetDeadline(prepared, time.Now().Add(12*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetDeadline(prepared, time.Now().Add(25*time.Hour)); err == nil {
+		t.Error("SetDeadline accepted a deadline after 24 hours")
+	}
+	if _, err := watch.Sign(nil); err != ErrNoPrivateKey {
+		t.Errorf("PublicKeySigner.Sign: err = %v, want ErrNoPrivateKey", err)
+	}
+	unsigned, err := Export(prepared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := unsigned.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Offline: sign the file
+	parsed, err := ParseUnsigned(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Signer != keys.PublicKey() || parsed.Type != model.Transfer || parsed.Network != model.Data.Testnet.ID {
+		t.Errorf("ParseUnsigned = %+v", parsed)
+	}
+	if _, err := ParseUnsigned([]byte(unsigned.Hex())); err != nil {
+		t.Errorf("ParseUnsigned(hex): %v", err)
+	}
+	// A tampered readable form shows the transaction of Data again
+	const other = "TB3YJTWKY5IY62ABUIDLJ3YVEPX56OSVWULCQSWJ"
+	tampered := bytes.Replace(file, []byte(address.String()), []byte(other), -1)
+	if bytes.Equal(tampered, file) {
+		t.Fatal("the recipient is not in the readable form")
+	}
+	reviewed, err := ParseUnsigned(tampered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(reviewed.Transaction, []byte(address.String())) || bytes.Contains(reviewed.Transaction, []byte(other)) {
+		t.Errorf("ParseUnsigned kept the tampered recipient: %s", reviewed.Transaction)
+	}
+	signed, err := SignOffline(parsed, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Online: announce later
+	result, err := Announce(signed, account.client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Code != 1 {
+		t.Errorf("announce result = %+v", result)
+	}
+
+	signed.Signature = signed.Signature[2:] + "00"
+	if _, err := VerifyAnnounce(signed); err == nil {
+		t.Error("VerifyAnnounce accepted a wrong signature")
+	}
+}
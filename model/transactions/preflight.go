@@ -0,0 +1,147 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+// The sender does not own the levy of an attached mosaic, it is also an ErrInsufficientBalance
+var ErrInsufficientLevy = fmt.Errorf("%w for the mosaic levy", requests.ErrInsufficientBalance)
+
+// The quantities an account pays for a transaction, by mosaic full name
+type cost struct {
+	paid   map[string]base.Amount
+	levies map[string]base.Amount
+}
+
+// Check that the accounts own what they pay for a prepared transaction, before announcing it:
+// the fees, the rental fees, the transferred XEM and mosaics and the levies of the attached mosaics.
+// The levies are the Levies of a transfer from PrepareMosaic, or computed from the mosaic definitions of NIS.
+// The fees are charged to their debtor, see model.Debtor.
+// param client - An NIS endpoint struct
+// param entity - A prepared transaction
+// return - An error wrapping requests.ErrInsufficientBalance, ErrInsufficientLevy when only a levy is missing
+func Preflight(client *requests.Client, entity base.Transaction) error {
+	return PreflightCtx(context.Background(), client, entity)
+}
+
+// PreflightCtx is Preflight bounded by ctx
+func PreflightCtx(ctx context.Context, client *requests.Client, entity base.Transaction) error {
+	if client == nil || entity == nil {
+		return errors.New("missing parameter !")
+	}
+	network := int(int8(uint32(entity.GetCommon().Version) >> 24))
+	costs := make(map[base.Address]*cost)
+	if err := collectCosts(ctx, client, entity, network, costs); err != nil {
+		return err
+	}
+	for address, c := range costs {
+		owned, err := ownedBy(ctx, client, address)
+		if err != nil {
+			return err
+		}
+		for name, paid := range c.paid {
+			if owned[name] < paid {
+				return fmt.Errorf("%w: %s owns %d %s, %d needed", requests.ErrInsufficientBalance, address, owned[name], name, paid)
+			}
+		}
+		for name, levy := range c.levies {
+			if need := c.paid[name] + levy; owned[name] < need {
+				return fmt.Errorf("%w: %s owns %d %s, %d needed", ErrInsufficientLevy, address, owned[name], name, need)
+			}
+		}
+	}
+	return nil
+}
+
+// Collect the costs of a transaction by address of the paying account
+func collectCosts(ctx context.Context, client *requests.Client, tx base.Transaction, network int, costs map[base.Address]*cost) error {
+	debtor, err := model.Debtor(tx, network)
+	if err != nil {
+		return err
+	}
+	c, ok := costs[debtor]
+	if !ok {
+		c = &cost{paid: make(map[string]base.Amount), levies: make(map[string]base.Amount)}
+		costs[debtor] = c
+	}
+	c.paid[model.XEMName] += tx.GetCommon().Fee
+
+	switch t := tx.(type) {
+	case *base.MultiSignTransaction:
+		for _, s := range t.Signatures {
+			c.paid[model.XEMName] += s.Fee
+		}
+		return collectCosts(ctx, client, t.OtherTrans.(base.Transaction), network, costs)
+	case *base.TransactionMosaic:
+		return collectCosts(ctx, client, transferOf(t), network, costs)
+	case *base.TransferTransaction:
+		if len(t.Mosaics) == 0 {
+			c.paid[model.XEMName] += t.Amount
+			return nil
+		}
+		for _, m := range t.Mosaics {
+			c.paid[utils.MosaicIdToName(m.MosaicID)] += model.TransferredQuantity(t.Amount, m.Quantity)
+		}
+		levies := t.Levies
+		if levies == nil {
+			var err error
+			if levies, err = fetchLevies(ctx, client, t); err != nil {
+				return err
+			}
+		}
+		for _, l := range levies {
+			c.levies[utils.MosaicIdToName(l.Levy.MosaicID)] += l.Quantity
+		}
+	case *base.ProvisionNamespaceTransaction:
+		c.paid[model.XEMName] += t.RentalFee
+	case *base.MosaicDefinitionCreationTransaction:
+		c.paid[model.XEMName] += t.CreationFee
+	}
+	return nil
+}
+
+// Compute the levies of a transfer from the mosaic definitions of NIS
+func fetchLevies(ctx context.Context, client *requests.Client, t *base.TransferTransaction) ([]base.MosaicLevy, error) {
+	definitions := make(map[string]base.MosaicDefinition)
+	fetched := make(map[string]bool)
+	for _, m := range t.Mosaics {
+		namespace := m.MosaicID.NamespaceID
+		if utils.MosaicIdToName(m.MosaicID) == model.XEMName || fetched[namespace] {
+			continue
+		}
+		fetched[namespace] = true
+		pairs, err := client.MosaicDefinitionsCtx(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+		for _, pair := range pairs {
+			definitions[utils.MosaicIdToName(pair.Mosaic.ID)] = pair.Mosaic
+		}
+	}
+	return model.CalculateLevies(t.Amount, definitions, t.Mosaics)
+}
+
+// Gets the quantities owned by an account, by mosaic full name
+func ownedBy(ctx context.Context, client *requests.Client, address base.Address) (map[string]base.Amount, error) {
+	account, err := client.AccountDataCtx(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	mosaics, err := client.MosaicsOwnedCtx(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[string]base.Amount)
+	for _, m := range mosaics {
+		owned[utils.MosaicIdToName(m.MosaicID)] = m.Quantity
+	}
+	owned[model.XEMName] = account.Account.Balance
+	return owned, nil
+}
@@ -0,0 +1,126 @@
+package transactions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/com/requests/nistest"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+func TestPreflightLevy(t *testing.T) {
+	account := newTestAccount(t)
+	keys, address, srv, client := account.keys, account.address, account.srv, account.client
+	const sink = "TB3YJTWKY5IY62ABUIDLJ3YVEPX56OSVWULCQSWJ"
+	bar := base.MosaicID{NamespaceID: "foo", Name: "bar"}
+	fee := base.MosaicID{NamespaceID: "foo", Name: "fee"}
+	properties := []base.Properties{{Name: "divisibility", Value: "0"}, {Name: "initialSupply", Value: "1000000"}}
+	definitions := map[string]base.MosaicDefinition{
+		"foo:bar": {ID: bar, Properties: properties, Levy: base.Levy{Type: model.LevyAbsolute, Recipient: sink, MosaicID: fee, Fee: 5}},
+		"foo:fee": {ID: fee, Properties: properties},
+	}
+
+	for _, d := range definitions {
+		srv.AddMosaicDefinition(d)
+	}
+	srv.SetMosaics(address.String(), []base.Mosaic{{MosaicID: bar, Quantity: 100}})
+
+	tx := Transfer{Amount: base.XEM, Recipient: sink, Mosaics: []base.Mosaic{{MosaicID: bar, Quantity: 10}}}
+	prepared, err := tx.PrepareMosaic(keys, definitions, client, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	levies := prepared.(*base.TransferTransaction).Levies
+	if len(levies) != 1 || levies[0].Mosaic != bar || levies[0].Levy.MosaicID != fee || levies[0].Quantity != 5 {
+		t.Fatalf("Levies = %+v", levies)
+	}
+
+	// The sender owns the transferred mosaic but not the levy mosaic
+	err = Preflight(client, prepared)
+	if !errors.Is(err, ErrInsufficientLevy) || !errors.Is(err, requests.ErrInsufficientBalance) {
+		t.Errorf("Preflight without the levy mosaic: err = %v", err)
+	}
+	// The levies are computed from the definitions of NIS when missing
+	prepared.(*base.TransferTransaction).Levies = nil
+	if err := Preflight(client, prepared); !errors.Is(err, ErrInsufficientLevy) {
+		t.Errorf("Preflight without the levies: err = %v", err)
+	}
+	if _, err := Send(keys, prepared, client); !errors.Is(err, requests.ErrInsufficientBalance) {
+		t.Errorf("Send without the levy mosaic: err = %v", err)
+	}
+
+	srv.SetMosaics(address.String(), []base.Mosaic{{MosaicID: bar, Quantity: 100}, {MosaicID: fee, Quantity: 5}})
+	if err := Preflight(client, prepared); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Send(keys, prepared, client); err != nil {
+		t.Fatal(err)
+	}
+	srv.Confirm()
+	var paid base.Quantity
+	for _, m := range srv.Mosaics(sink) {
+		if m.MosaicID == fee {
+			paid = m.Quantity
+		}
+	}
+	if paid != 5 {
+		t.Errorf("The levy recipient owns %d foo:fee, want 5", paid)
+	}
+}
+
+func TestPreflightMultisig(t *testing.T) {
+	signers := make(map[string]*MemorySigner)
+	addresses := make(map[string]base.Address)
+	for name, key := range map[string]string{
+		"multisig":  "1b3a8d8e3f2a4d0c9e7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e5d",
+		"initiator": testPrivateKey,
+		"cosigner":  "2c4b9e9f4a3b5e1dafac7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e",
+	} {
+		s, err := NewMemorySigner(key, model.Data.Testnet.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		address, err := utils.PubToAddress(s.PublicKey(), model.Data.Testnet.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signers[name], addresses[name] = s, address
+	}
+
+	srv := nistest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	// The initiator and the cosigner own no XEM
+	tx := Transfer{Amount: 10 * base.XEM, Recipient: addresses["initiator"], IsMultisig: true, MultisigAccount: signers["multisig"].PublicKey()}
+	prepared, err := tx.Prepare(signers["initiator"], model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := prepared.(*base.MultiSignTransaction)
+	m.Signatures = []base.MultiSignSignatureTransaction{{Fee: model.SignatureTransaction}}
+	fees := m.Fee + m.OtherTrans.(*base.TransferTransaction).Fee + model.SignatureTransaction
+
+	srv.SetAccount(requests.AccountInfo{Address: addresses["multisig"].String(), Balance: 10*base.XEM + fees - 1})
+	if err := Preflight(client, prepared); !errors.Is(err, requests.ErrInsufficientBalance) {
+		t.Errorf("Preflight with a multisig account short of 1 micro-XEM: err = %v", err)
+	}
+	srv.SetAccount(requests.AccountInfo{Address: addresses["multisig"].String(), Balance: 10*base.XEM + fees})
+	if err := Preflight(client, prepared); err != nil {
+		t.Errorf("Preflight of the multisig transaction: %v", err)
+	}
+
+	var cosignature MultisigSignature
+	cosignature.OtherHash.Data = "e1f7b2e1dd6e1f5ad8a4c34e1bc1ba4b41def2b8d44b6b0e5bd7d82a3b6a6a54"
+	cosignature.OtherAccount = addresses["multisig"]
+	signature, err := cosignature.Prepare(signers["cosigner"], model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Preflight(client, signature); err != nil {
+		t.Errorf("Preflight of the cosignature: %v", err)
+	}
+}
@@ -1,7 +1,11 @@
 package transactions
 
 import (
+	"strings"
+
+	"github.com/isarq/nem-sdk-go/base"
 	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/crypto/keystore"
 	"github.com/isarq/nem-sdk-go/extras"
 	"github.com/isarq/nem-sdk-go/model"
 	"github.com/isarq/nem-sdk-go/utils"
@@ -13,35 +17,80 @@ type Common struct {
 	IsHW                 bool
 }
 
-// Serialize a transaction and broadcast it to the network
-// param common - A common struct
+// Set the private key of common from a wallet account, decrypted with common.Password
+// param account - A NanoWallet account, e.g. wallet.Accounts["0"]
+func (c *Common) Unlock(account model.WalletAccount) error {
+	privateKey, err := account.PrivateKey(c.Password)
+	if err != nil {
+		return err
+	}
+	c.PrivateKey = privateKey
+	return nil
+}
+
+// Set the private key of common from a keystore account, unlocked with common.Password
+// param ks - A keystore
+// param address - The address of the account
+func (c *Common) UnlockKeystore(ks *keystore.Keystore, address string) error {
+	kp, err := ks.Unlock(address, c.Password)
+	if err != nil {
+		return err
+	}
+	c.PrivateKey = kp.PrivateString()
+	return nil
+}
+
+// Serialize a transaction, sign it and broadcast it to the network
+// param signer - A Signer, e.g. a Common struct
 // param entity - A prepared transaction struct
 // param endpoint - An NIS endpoint struct
 // return - An announce transaction promise of the com.requests service
-func Send(common Common, entity interface{}, endpoint *requests.Client) (*requests.NemAnnounceResult, error) {
-	if extras.IsEmpty(common) || extras.IsEmpty(entity) || extras.IsEmpty(endpoint) {
+func Send(signer Signer, entity interface{}, endpoint *requests.Client) (*requests.NemAnnounceResult, error) {
+	if signer == nil || extras.IsEmpty(entity) || extras.IsEmpty(endpoint) {
 		return nil, errors.New("Missing parameter !")
 	}
-	if len(common.PrivateKey) != 64 && len(common.PrivateKey) != 66 {
-		return nil, errors.New("Invalid private key, length must be 64 or 66 characters !")
-	}
-	if !utils.IsHexadecimal(common.PrivateKey) {
-		return nil, errors.New("Private key must be hexadecimal only !")
-	}
-	kp, err := model.KeyPairCreate(common.PrivateKey)
+	obj, err := sign(signer, entity)
 	if err != nil {
 		return nil, err
 	}
+	result, err := endpoint.Announce(obj)
+	if err != nil {
+		return result, err
+	}
+	if tx, ok := entity.(base.Transaction); ok {
+		return withHashes(result, tx)
+	}
+	return result, nil
+}
+
+// Serialize a transaction and sign it, to announce it later
+// The hash of the transaction (see Hash) can be stored before announcing, Reannounce
+// announces it as many times as needed.
+// param signer - A Signer, e.g. a Common struct
+// param entity - A prepared transaction struct
+// return - The RequestAnnounce to announce
+func Sign(signer Signer, entity base.Transaction) (requests.RequestAnnounce, error) {
+	if signer == nil || entity == nil {
+		return requests.RequestAnnounce{}, errors.New("Missing parameter !")
+	}
+	return sign(signer, entity)
+}
+
+func sign(signer Signer, entity interface{}) (requests.RequestAnnounce, error) {
+	// A Common struct with an invalid private key fails to sign below
+	publicKey := signer.PublicKey()
+	if tx, ok := entity.(base.Transaction); ok && publicKey != "" && !strings.EqualFold(tx.GetCommon().Signer, publicKey) {
+		return requests.RequestAnnounce{}, errors.New("The transaction signer does not match the signer public key !")
+	}
 
 	result := utils.SerializeTransaction(entity)
-	signature, err := kp.Sign(result)
+	signature, err := signer.Sign(result)
 	if err != nil {
-		return nil, err
+		return requests.RequestAnnounce{}, err
 	}
 
-	obj := requests.RequestAnnounce{
+	return requests.RequestAnnounce{
 		Data:      utils.Bt2Hex([]byte(result)),
 		Signature: utils.Bt2Hex(signature),
-	}
-	return endpoint.Announce(obj)
+	}, nil
 }
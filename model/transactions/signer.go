@@ -0,0 +1,184 @@
+package transactions
+
+import (
+	"errors"
+
+	"github.com/isarq/nem-sdk-go/crypto"
+	"github.com/isarq/nem-sdk-go/crypto/keystore"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+// A Signer signs the transactions of an account, its private key may live elsewhere:
+// in memory, in a keystore, in a remote signing daemon or in a hardware wallet.
+// Common, MemorySigner and KeystoreSigner are Signers.
+type Signer interface {
+	// PublicKey returns the public key of the account
+	PublicKey() string
+	// Network returns the network id of the account, 0 when it signs for any network
+	Network() int
+	// Sign returns the signature of a serialized transaction
+	Sign(payload []byte) ([]byte, error)
+}
+
+// A MessageEncrypter encrypts the messages sent to a recipient.
+// The Signers holding the private key implement it, an encrypted message
+// can not be prepared with the other Signers.
+type MessageEncrypter interface {
+	// EncryptMessage returns the payload of an encrypted message in hexadecimal
+	EncryptMessage(recipientPublicKey, message string) (string, error)
+}
+
+// Gets the public key of a Signer preparing a transaction
+// param signer - A Signer
+// param network - The network id of the transaction
+// return - The signer public key
+func signerPublicKey(signer Signer, network int) (string, error) {
+	if signer == nil {
+		return "", errors.New("missing parameter !")
+	}
+	publicKey := signer.PublicKey()
+	if !utils.IsPublicKeyValid(publicKey) {
+		return "", errors.New("Invalid signer public key !")
+	}
+	if n := signer.Network(); n != 0 && n != network {
+		return "", errors.New("signer network does not match the transaction network !")
+	}
+	return publicKey, nil
+}
+
+// Gets the public key of the private key of common, empty when the private key is not valid
+func (c Common) PublicKey() string {
+	kp, err := c.keyPair()
+	if err != nil {
+		return ""
+	}
+	return kp.PublicString()
+}
+
+// A Common struct signs for any network
+func (c Common) Network() int {
+	return 0
+}
+
+// Sign a payload with the private key of common
+// param payload - A serialized transaction
+// return - The signature
+func (c Common) Sign(payload []byte) ([]byte, error) {
+	kp, err := c.keyPair()
+	if err != nil {
+		return nil, err
+	}
+	return kp.Sign(payload)
+}
+
+// Encrypt a message with the private key of common
+// param recipientPublicKey - The public key of the recipient
+// param message - A text message
+// return - The encrypted payload
+func (c Common) EncryptMessage(recipientPublicKey, message string) (string, error) {
+	return crypto.Encode(c.PrivateKey, recipientPublicKey, message)
+}
+
+func (c Common) keyPair() (*model.KeyPair, error) {
+	if len(c.PrivateKey) != 64 && len(c.PrivateKey) != 66 {
+		return nil, errors.New("Invalid private key, length must be 64 or 66 characters !")
+	}
+	if !utils.IsHexadecimal(c.PrivateKey) {
+		return nil, errors.New("Private key must be hexadecimal only !")
+	}
+	return model.KeyPairCreate(utils.FixPrivateKey(c.PrivateKey))
+}
+
+// A MemorySigner holds a key pair in memory
+type MemorySigner struct {
+	kp      *model.KeyPair
+	network int
+}
+
+// Create a signer from a private key
+// param privateKey - A private key
+// param network - A network id, 0 for any network
+// return - A MemorySigner point
+func NewMemorySigner(privateKey string, network int) (*MemorySigner, error) {
+	kp, err := Common{PrivateKey: privateKey}.keyPair()
+	if err != nil {
+		return nil, err
+	}
+	return &MemorySigner{kp: kp, network: network}, nil
+}
+
+// Create a signer from a key pair, e.g. a key pair of model.Wallet.Open
+// param kp - A KeyPair
+// param network - A network id, 0 for any network
+// return - A MemorySigner point
+func NewKeyPairSigner(kp *model.KeyPair, network int) *MemorySigner {
+	return &MemorySigner{kp: kp, network: network}
+}
+
+func (s *MemorySigner) PublicKey() string {
+	return s.kp.PublicString()
+}
+
+func (s *MemorySigner) Network() int {
+	return s.network
+}
+
+func (s *MemorySigner) Sign(payload []byte) ([]byte, error) {
+	return s.kp.Sign(payload)
+}
+
+func (s *MemorySigner) EncryptMessage(recipientPublicKey, message string) (string, error) {
+	return crypto.Encode(s.kp.PrivateString(), recipientPublicKey, message)
+}
+
+// A KeystoreSigner unlocks a keystore account for every signature,
+// the private key is only held in memory while signing.
+type KeystoreSigner struct {
+	ks       *keystore.Keystore
+	account  keystore.Account
+	password string
+}
+
+// Create a signer of a keystore account, the password is checked once
+// param ks - A keystore
+// param address - The address of the account
+// param password - The password of the account
+// return - A KeystoreSigner point
+func NewKeystoreSigner(ks *keystore.Keystore, address, password string) (*KeystoreSigner, error) {
+	if ks == nil {
+		return nil, errors.New("missing parameter !")
+	}
+	account, err := ks.Account(address)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ks.Unlock(address, password); err != nil {
+		return nil, err
+	}
+	return &KeystoreSigner{ks: ks, account: account, password: password}, nil
+}
+
+func (s *KeystoreSigner) PublicKey() string {
+	return s.account.PublicKey
+}
+
+func (s *KeystoreSigner) Network() int {
+	return s.account.Network
+}
+
+func (s *KeystoreSigner) Sign(payload []byte) ([]byte, error) {
+	kp, err := s.ks.Unlock(s.account.Address, s.password)
+	if err != nil {
+		return nil, err
+	}
+	return kp.Sign(payload)
+}
+
+func (s *KeystoreSigner) EncryptMessage(recipientPublicKey, message string) (string, error) {
+	kp, err := s.ks.Unlock(s.account.Address, s.password)
+	if err != nil {
+		return "", err
+	}
+	return crypto.Encode(kp.PrivateString(), recipientPublicKey, message)
+}
@@ -0,0 +1,259 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+const (
+	// The default interval between two polls of a Tracker, a block is harvested every minute on average
+	DefaultTrackInterval = 15 * time.Second
+	// The number of blocks NIS can roll back, a transaction with more confirmations is final
+	MaxRollback = 360
+)
+
+var ErrExpired = errors.New("the transaction deadline expired before its confirmation !")
+
+// The state of a tracked transaction
+type TrackState int
+
+const (
+	// The transaction is not known by the node
+	Pending TrackState = iota
+	// The transaction waits in the unconfirmed transactions of the node
+	Unconfirmed
+	// The transaction is included in a block
+	Confirmed
+	// The block including the transaction was rolled back
+	RolledBack
+	// The deadline expired before the transaction was included in a block
+	Expired
+)
+
+func (s TrackState) String() string {
+	switch s {
+	case Pending:
+		return "pending"
+	case Unconfirmed:
+		return "unconfirmed"
+	case Confirmed:
+		return "confirmed"
+	case RolledBack:
+		return "rolled back"
+	case Expired:
+		return "expired"
+	}
+	return "unknown"
+}
+
+// A TrackEvent is a change of a tracked transaction
+type TrackEvent struct {
+	// Hash is the hash of the transaction
+	Hash string
+	// State is the state of the transaction
+	State TrackState
+	// Height is the height of the block including the transaction, the former height when RolledBack
+	Height int64
+	// Confirmations is the number of blocks from the including block to the last block, 0 when not confirmed
+	Confirmations int64
+	// Err is ErrExpired when Expired, or the error of a failed poll, the state is then unchanged
+	Err error
+}
+
+// A Tracker waits for a transaction to reach a number of confirmations.
+// It polls the node for the transaction in the unconfirmed transactions and in the chain,
+// and reports the rollbacks of its block.
+//
+//	tracker, err := transactions.TrackTransaction(client, tx)
+//	tracker.Confirmations = 10
+//	for event := range tracker.Watch(ctx) {
+//		fmt.Println(event.State, event.Confirmations)
+//	}
+type Tracker struct {
+	// Client is the node polled
+	Client *requests.Client
+	// Hash is the hash of the transaction, see Hash
+	Hash string
+	// Deadline is the deadline of the transaction (NEM time stamp), it can not be included after
+	Deadline int64
+	// Address is an account of the transaction, to find it in the unconfirmed transactions (optional)
+	Address base.Address
+	// Confirmations is the number of confirmations to wait for, 1 when 0, up to MaxRollback
+	Confirmations int64
+	// Interval is the time between two polls, DefaultTrackInterval when 0
+	Interval time.Duration
+}
+
+// Create a tracker of a transaction
+// param client - An NIS endpoint struct
+// param hash - The hash of the transaction
+// param deadline - The deadline of the transaction (NEM time stamp)
+// return - A Tracker point waiting for one confirmation
+func NewTracker(client *requests.Client, hash string, deadline int64) *Tracker {
+	return &Tracker{Client: client, Hash: hash, Deadline: deadline}
+}
+
+// Create a tracker of a prepared transaction, watching the unconfirmed transactions of its signer
+// param client - An NIS endpoint struct
+// param entity - A prepared transaction, e.g. given to Send
+// return - A Tracker point waiting for one confirmation
+func TrackTransaction(client *requests.Client, entity base.Transaction) (*Tracker, error) {
+	hash, err := Hash(entity)
+	if err != nil {
+		return nil, err
+	}
+	common := entity.GetCommon()
+	if common.Deadline == nil {
+		return nil, errors.New("missing transaction deadline !")
+	}
+	address, err := utils.PubToAddress(common.Signer, int(int8(uint32(common.Version)>>24)))
+	if err != nil {
+		return nil, err
+	}
+	t := NewTracker(client, hash, *common.Deadline)
+	t.Address = address
+	return t, nil
+}
+
+// Watch the transaction until it reaches the confirmations, its deadline expires or ctx is done
+// The events are sent on every change: Unconfirmed, Confirmed with each new confirmation,
+// RolledBack then the new state, and Expired last.
+// param ctx - Bounds the watch
+// return - A channel of TrackEvent, closed at the end of the watch
+func (t *Tracker) Watch(ctx context.Context) <-chan TrackEvent {
+	ch := make(chan TrackEvent)
+	go func() {
+		defer close(ch)
+		interval := t.Interval
+		if interval <= 0 {
+			interval = DefaultTrackInterval
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		last := TrackEvent{Hash: t.Hash, State: Pending}
+		for {
+			events, done := t.poll(ctx, last)
+			for _, event := range events {
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+					return
+				}
+				if event.Err == nil {
+					last = event
+				}
+			}
+			if done {
+				return
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// Wait for the transaction to reach the confirmations
+// param ctx - Bounds the wait
+// return - The last event, an error when the deadline expired (ErrExpired) or ctx is done
+func (t *Tracker) Wait(ctx context.Context) (TrackEvent, error) {
+	last := TrackEvent{Hash: t.Hash, State: Pending}
+	for event := range t.Watch(ctx) {
+		if event.Err == nil || event.State == Expired {
+			last = event
+		}
+		if event.State == Expired {
+			return last, event.Err
+		}
+	}
+	if last.State == Confirmed && last.Confirmations >= t.target() {
+		return last, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return last, err
+	}
+	return last, errors.New("the watch of the transaction ended early !")
+}
+
+func (t *Tracker) target() int64 {
+	if t.Confirmations <= 0 {
+		return 1
+	}
+	if t.Confirmations > MaxRollback {
+		return MaxRollback
+	}
+	return t.Confirmations
+}
+
+// Poll the node once
+// param last - The last event sent
+// return - The events to send, true when the watch is over
+func (t *Tracker) poll(ctx context.Context, last TrackEvent) ([]TrackEvent, bool) {
+	failed := func(err error) ([]TrackEvent, bool) {
+		event := last
+		event.Err = err
+		return []TrackEvent{event}, false
+	}
+	var events []TrackEvent
+
+	pair, err := t.Client.ByHashCtx(ctx, t.Hash)
+	if err != nil && !notFound(err) {
+		return failed(err)
+	}
+	if err == nil {
+		height, err := t.Client.HeightCtx(ctx)
+		if err != nil {
+			return failed(err)
+		}
+		event := TrackEvent{Hash: t.Hash, State: Confirmed, Height: pair.Meta.Height}
+		event.Confirmations = height.Height - event.Height + 1
+		if last.State == Confirmed && last.Height != event.Height {
+			events = append(events, TrackEvent{Hash: t.Hash, State: RolledBack, Height: last.Height})
+		}
+		if last.State != Confirmed || last.Height != event.Height || last.Confirmations != event.Confirmations {
+			events = append(events, event)
+		}
+		return events, event.Confirmations >= t.target()
+	}
+
+	// Not in the chain, or not anymore
+	if last.State == Confirmed {
+		events = append(events, TrackEvent{Hash: t.Hash, State: RolledBack, Height: last.Height})
+		last = TrackEvent{Hash: t.Hash, State: Pending}
+	}
+	if t.Deadline != 0 && utils.CreateNEMTimeStamp() > t.Deadline {
+		return append(events, TrackEvent{Hash: t.Hash, State: Expired, Err: ErrExpired}), true
+	}
+	state := Pending
+	if t.Address != "" {
+		unconfirmed, err := t.Client.UnconfirmedTransactionsCtx(ctx, t.Address)
+		if err != nil {
+			return append(events, TrackEvent{Hash: t.Hash, State: last.State, Err: err}), false
+		}
+		for _, tx := range unconfirmed {
+			if hash, err := Hash(tx); err == nil && hash == t.Hash {
+				state = Unconfirmed
+				break
+			}
+		}
+	}
+	if state != last.State || len(events) > 0 {
+		events = append(events, TrackEvent{Hash: t.Hash, State: state})
+	}
+	return events, false
+}
+
+// Report if an error of ByHash is an unknown transaction
+func notFound(err error) bool {
+	var nisErr *requests.NisError
+	return errors.As(err, &nisErr) && nisErr.StatusCode >= 400 && nisErr.StatusCode < 500
+}
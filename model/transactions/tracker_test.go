@@ -0,0 +1,74 @@
+package transactions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/com/requests/nistest"
+	"github.com/isarq/nem-sdk-go/model"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+func TestTracker(t *testing.T) {
+	account := newTestAccount(t)
+	keys, address, srv, client := account.keys, account.address, account.srv, account.client
+
+	tx := Transfer{Amount: base.XEM, Recipient: address}
+	prepared, err := tx.Prepare(keys, model.Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Send(keys, prepared, client); err != nil {
+		t.Fatal(err)
+	}
+
+	tracker, err := TrackTransaction(client, prepared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker.Confirmations, tracker.Interval = 2, time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	events := tracker.Watch(ctx)
+
+	expect := func(state TrackState, height, confirmations int64) {
+		t.Helper()
+		event, ok := <-events
+		if !ok {
+			t.Fatalf("watch ended, want %s", state)
+		}
+		if event.Err != nil || event.State != state || event.Height != height || event.Confirmations != confirmations {
+			t.Fatalf("event = %+v, want %s at %d with %d confirmations", event, state, height, confirmations)
+		}
+	}
+	expect(Unconfirmed, 0, 0)
+	srv.Confirm()
+	expect(Confirmed, 2, 1)
+	srv.Rollback(1, false)
+	expect(RolledBack, 2, 0)
+	expect(Unconfirmed, 0, 0)
+	srv.Confirm()
+	expect(Confirmed, 2, 1)
+	srv.Confirm()
+	expect(Confirmed, 2, 2)
+	if event, ok := <-events; ok {
+		t.Errorf("event after the confirmations: %+v", event)
+	}
+}
+
+func TestTrackerExpired(t *testing.T) {
+	srv := nistest.NewServer()
+	defer srv.Close()
+
+	hash := "2c0d7ef0eda1e2ec1aa65f3fd1a8a2d3fbc2bb8f3b2d1c3c0a4ae0d4e1bb2f77"
+	tracker := NewTracker(srv.Client(), hash, utils.CreateNEMTimeStamp()-1)
+	tracker.Interval = time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	event, err := tracker.Wait(ctx)
+	if err != ErrExpired || event.State != Expired {
+		t.Errorf("Wait = %+v, %v, want ErrExpired", event, err)
+	}
+}
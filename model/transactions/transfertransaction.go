@@ -2,9 +2,6 @@ package transactions
 
 import (
 	"errors"
-	"fmt"
-	"math"
-	"strings"
 
 	"github.com/isarq/nem-sdk-go/base"
 	"github.com/isarq/nem-sdk-go/com/requests"
@@ -16,18 +13,21 @@ import (
 type txPrepare struct {
 	senderPublicKey        string
 	recipientCompressedKey string
-	amount                 float64
+	amount                 base.Amount
 	message                base.Message
-	msgFee                 float64
+	msgFee                 base.Amount
 	due                    int64
 	mosaics                []base.Mosaic
-	mosaicsFee             float64
+	mosaicsFee             base.Amount
+	levies                 []base.MosaicLevy
 	network                int
 }
 
+// An un-prepared transfer transaction.
+// Amount is in micro-XEM, it is the multiplier of the mosaics when Mosaics are attached.
 type Transfer struct {
-	Amount             float64       `json:"amount"`
-	Recipient          string        `json:"recipient"`
+	Amount             base.Amount   `json:"amount"`
+	Recipient          base.Address  `json:"recipient"`
 	RecipientPublicKey string        `json:"recipientPublicKey"`
 	IsMultisig         bool          `json:"isMultisig"`
 	MultisigAccount    string        `json:"multisigAccount"`
@@ -46,17 +46,17 @@ func (t *Transfer) GetType() int {
 }
 
 // Prepare a transfer transaction struct
-// param common - A common struct
+// param signer - A Signer, e.g. a Common struct
 // param r - An un-prepared TransferTransaction method
 // param network - A network id
 // return - A [TransferTransaction] struct
 // link http://bob.nem.ninja/docs/#transferTransaction
-func (r *Transfer) Prepare(common Common, network int) (base.Transaction, error) {
+func (r *Transfer) Prepare(signer Signer, network int) (base.Transaction, error) {
 	var msc txPrepare
-	if extras.IsEmpty(common) || extras.IsEmpty(network) {
+	if signer == nil || extras.IsEmpty(network) {
 		return nil, errors.New("missing parameter !")
 	}
-	kp, err := model.KeyPairCreate(common.PrivateKey)
+	publicKey, err := signerPublicKey(signer, network)
 	if err != nil {
 		return nil, err
 	}
@@ -70,14 +70,20 @@ func (r *Transfer) Prepare(common Common, network int) (base.Transaction, error)
 			return nil, errors.New("must place a publickey of the multifirm account")
 		}
 	} else {
-		msc.senderPublicKey = kp.PublicString()
+		msc.senderPublicKey = publicKey
 	}
 
-	msc.recipientCompressedKey = strings.ToUpper(strings.Replace(r.Recipient, "-", "", -1))
+	msc.recipientCompressedKey, err = recipientAddress(r.Recipient, network)
+	if err != nil {
+		return nil, err
+	}
 
-	msc.amount = math.Round(r.Amount * 1000000)
+	msc.amount = r.Amount
 
-	msc.message = MsgPrepare(common, r)
+	msc.message, err = MsgPrepare(signer, r)
+	if err != nil {
+		return nil, err
+	}
 
 	msc.msgFee = model.CalculateMessage(msc.message, false)
 
@@ -90,46 +96,54 @@ func (r *Transfer) Prepare(common Common, network int) (base.Transaction, error)
 
 	rt := constructtx(msc)
 	if r.IsMultisig && r.MultisigAccount != "" {
-		return MultisigWrapper(kp.PublicString(), rt, msc.due, network), nil
+		return MultisigWrapper(publicKey, rt, msc.due, network), nil
 	}
 	return rt, nil
 }
 
 // Prepare a mosaic transfer transaction struct
-// param common - A common struct
+// param signer - A Signer, e.g. a Common struct
 // param tx - The un-prepared transfer transaction struct
 // param mosaicDefinitionMetaDataPair - The mosaicDefinitionMetaDataPair object with properties of mosaics to send
+// param client - An NIS endpoint struct, gets the supply of the mosaics
 // param network - A network id
-// return - A [TransferTransaction] struct ready for serialization
+// return - A [TransferTransaction] struct ready for serialization, with the levies of the attached mosaics
 // link http://bob.nem.ninja/docs/#transferTransaction
-func (r *Transfer) PrepareMosaic(common Common, mosaicDefinitionMetaDataPair map[string]base.MosaicDefinition,
-	client *requests.Client, network int) base.Transaction {
-	supplys := make(map[string]float64)
+func (r *Transfer) PrepareMosaic(signer Signer, mosaicDefinitionMetaDataPair map[string]base.MosaicDefinition,
+	client *requests.Client, network int) (base.Transaction, error) {
+	supplys := make(map[string]uint64)
 	var msc txPrepare
-	if extras.IsEmpty(common) || extras.IsEmpty(network) || extras.IsEmpty(mosaicDefinitionMetaDataPair) {
-		err := errors.New("missing parameter !")
-		panic(err)
+	if signer == nil || client == nil || extras.IsEmpty(network) || extras.IsEmpty(mosaicDefinitionMetaDataPair) {
+		return nil, errors.New("missing parameter !")
+	}
+	publicKey, err := signerPublicKey(signer, network)
+	if err != nil {
+		return nil, err
 	}
-	kp, _ := model.KeyPairCreate(common.PrivateKey)
 	if r.IsMultisig {
 		if r.MultisigAccount != "" {
 			if !utils.IsPublicKeyValid(r.MultisigAccount) {
-				panic(nil)
+				return nil, errors.New("Invalid public key!")
 			}
 			msc.senderPublicKey = r.MultisigAccount
 		} else {
-			err := errors.New("must place a publickey of the multifirm account")
-			panic(err)
+			return nil, errors.New("must place a publickey of the multifirm account")
 		}
 	} else {
-		msc.senderPublicKey = kp.PublicString()
+		msc.senderPublicKey = publicKey
 	}
 
-	msc.recipientCompressedKey = strings.ToUpper(strings.Replace(r.Recipient, "-", "", -1))
+	msc.recipientCompressedKey, err = recipientAddress(r.Recipient, network)
+	if err != nil {
+		return nil, err
+	}
 
-	msc.amount = math.Round(r.Amount * 1000000)
+	msc.amount = r.Amount
 
-	msc.message = MsgPrepare(common, r)
+	msc.message, err = MsgPrepare(signer, r)
+	if err != nil {
+		return nil, err
+	}
 
 	msc.msgFee = model.CalculateMessage(msc.message, false)
 
@@ -138,12 +152,21 @@ func (r *Transfer) PrepareMosaic(common Common, mosaicDefinitionMetaDataPair map
 		fullMosaicName := utils.MosaicIdToName(b.MosaicID)
 		supply, err := client.Supply(fullMosaicName)
 		if err != nil {
-			fmt.Println(utils.Struc2Json(err))
+			return nil, err
 		}
-		supplys[fullMosaicName] = float64(supply.Supply)
+		supplys[fullMosaicName] = uint64(supply.Supply)
 	}
 
-	msc.mosaicsFee = model.CalculateMosaics(msc.amount, mosaicDefinitionMetaDataPair, r.Mosaics, supplys)
+	msc.mosaicsFee, err = model.CalculateMosaics(msc.amount, mosaicDefinitionMetaDataPair, r.Mosaics, supplys)
+	if err != nil {
+		return nil, err
+	}
+
+	// The levies are paid by the sender on top of the fee, see Preflight
+	msc.levies, err = model.CalculateLevies(msc.amount, mosaicDefinitionMetaDataPair, r.Mosaics)
+	if err != nil {
+		return nil, err
+	}
 
 	if network == model.Data.Testnet.ID {
 		msc.due = 60
@@ -158,9 +181,24 @@ func (r *Transfer) PrepareMosaic(common Common, mosaicDefinitionMetaDataPair map
 
 	rt := constructtx(msc)
 	if r.IsMultisig && r.MultisigAccount != "" {
-		return MultisigWrapper(kp.PublicString(), rt, msc.due, network)
+		return MultisigWrapper(publicKey, rt, msc.due, network), nil
+	}
+	return rt, nil
+}
+
+// Validate the recipient of a transfer
+// param recipient - The recipient address, in plain or pretty form
+// param network - The network id of the transaction
+// return - The plain recipient address
+func recipientAddress(recipient base.Address, network int) (string, error) {
+	address, err := base.ParseAddress(string(recipient))
+	if err != nil {
+		return "", err
+	}
+	if !address.IsFromNetwork(network) {
+		return "", errors.New("Recipient address is not from the transaction network !")
 	}
-	return rt
+	return address.String(), nil
 }
 
 // Create a namespace provision transaction struct
@@ -176,13 +214,13 @@ func constructtx(msc txPrepare) *base.TransferTransaction {
 		version = model.GetVersion(2, msc.network)
 	}
 	data := CommonPart(model.Transfer, version, timeStamp, msc.due, msc.senderPublicKey)
-	var fee float64
+	var fee base.Amount
 	if !extras.IsEmpty(msc.mosaics) {
 		fee = msc.mosaicsFee
 	} else {
-		fee = model.CurrentFeeFactor * model.CalculateMinimum(msc.amount/1000000)
+		fee = model.FeeUnit * base.Amount(model.CalculateMinimum(uint64(msc.amount/base.XEM)))
 	}
-	totalFee := math.Floor((msc.msgFee + fee) * 1000000)
+	totalFee := msc.msgFee + fee
 
 	custom := base.TransferTransaction{
 		CommonTransaction: base.CommonTransaction{
@@ -194,11 +232,12 @@ func constructtx(msc txPrepare) *base.TransferTransaction {
 			Deadline:  data.Deadline,
 		},
 		Amount:    msc.amount,
-		Recipient: strings.ToUpper(strings.Replace(msc.recipientCompressedKey, "-", "", -1)),
+		Recipient: msc.recipientCompressedKey,
 
 		Message: msc.message,
 
 		Mosaics: msc.mosaics,
+		Levies:  msc.levies,
 	}
 	return &custom
 }
@@ -0,0 +1,69 @@
+package transactions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/model"
+)
+
+func TestPrepareMosaicErrors(t *testing.T) {
+	account := newTestAccount(t)
+	keys, client := account.keys, account.client
+	bar := base.MosaicID{NamespaceID: "foo", Name: "bar"}
+	definitions := map[string]base.MosaicDefinition{"foo:bar": {ID: bar}}
+	valid := Transfer{Amount: base.XEM, Recipient: "TB3YJTWKY5IY62ABUIDLJ3YVEPX56OSVWULCQSWJ", Mosaics: []base.Mosaic{{MosaicID: bar, Quantity: 1}}}
+
+	account.srv.AddMosaicDefinition(definitions["foo:bar"])
+
+	if _, err := valid.PrepareMosaic(keys, definitions, client, model.Data.Testnet.ID); err != nil {
+		t.Fatal(err)
+	}
+	// NIS knows the mosaic, the definitions do not
+	if _, err := valid.PrepareMosaic(keys, map[string]base.MosaicDefinition{"foo:baz": {}}, client, model.Data.Testnet.ID); !errors.Is(err, model.ErrUnknownMosaic) {
+		t.Errorf("missing definition: err = %v, want ErrUnknownMosaic", err)
+	}
+	if _, err := valid.PrepareMosaic(keys, definitions, nil, model.Data.Testnet.ID); err == nil {
+		t.Error("missing client: no error")
+	}
+	// The keys are for testnet
+	if _, err := valid.PrepareMosaic(keys, definitions, client, model.Data.Mainnet.ID); err == nil {
+		t.Error("signer of another network: no error")
+	}
+	if _, err := valid.PrepareMosaic(Common{PrivateKey: "not a key"}, definitions, client, model.Data.Testnet.ID); err == nil {
+		t.Error("invalid signer: no error")
+	}
+
+	for name, tx := range map[string]Transfer{
+		"encrypted message to a bad public key": func() Transfer {
+			tx := valid
+			tx.Message, tx.MessageType, tx.RecipientPublicKey = "secret", 2, "not a key"
+			return tx
+		}(),
+		"multisig without account": func() Transfer {
+			tx := valid
+			tx.IsMultisig = true
+			return tx
+		}(),
+		"invalid recipient": func() Transfer {
+			tx := valid
+			tx.Recipient = "TB3YJTWKY5IY62ABUIDLJ3YVEPX56OSVWULCQSWK"
+			return tx
+		}(),
+		"mainnet recipient": func() Transfer {
+			tx := valid
+			tx.Recipient = "NALICELGU3IVY4DPJKHYLSSVYFFWYS5QPLYEZDJJ"
+			return tx
+		}(),
+		"mosaic unknown to NIS": func() Transfer {
+			tx := valid
+			tx.Mosaics = []base.Mosaic{{MosaicID: base.MosaicID{NamespaceID: "foo", Name: "baz"}, Quantity: 1}}
+			return tx
+		}(),
+	} {
+		if _, err := tx.PrepareMosaic(keys, definitions, client, model.Data.Testnet.ID); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
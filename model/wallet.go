@@ -0,0 +1,296 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/isarq/nem-sdk-go/crypto"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+// The algorithms protecting the private key of a NanoWallet account
+const (
+	// The private key of the primary account is derived from the password,
+	// the other accounts are encrypted
+	AlgoBrain = "pass:6"
+	// The private key is random and encrypted with the password
+	AlgoPRNG = "pass:bip32"
+	// The private key is imported and encrypted with the password
+	AlgoPrivateKey = "pass:enc"
+	// The private key is held by a Trezor hardware wallet
+	AlgoTrezor = "trezor"
+)
+
+// The number of Keccak-256 iterations deriving the private key of a brain wallet
+const brainIterations = 6000
+
+var ErrWrongPassword = errors.New("wrong password !")
+
+// An account of a NanoWallet wallet
+type WalletAccount struct {
+	Brain     bool   `json:"brain"`
+	Algo      string `json:"algo"`
+	Encrypted string `json:"encrypted"`
+	Iv        string `json:"iv"`
+	Address   string `json:"address"`
+	Label     string `json:"label"`
+	Network   int    `json:"network"`
+	// Child is the public key of the bip32 child account, kept as read
+	Child string `json:"child,omitempty"`
+}
+
+// A NanoWallet wallet, as stored in a .wlt file
+// Accounts are indexed by "0", "1", ..., "0" is the primary account.
+type Wallet struct {
+	PrivateKey string                   `json:"privateKey"`
+	Name       string                   `json:"name"`
+	Accounts   map[string]WalletAccount `json:"accounts"`
+}
+
+// Create a wallet with a random private key
+// param name - The wallet name
+// param password - The password encrypting the private key
+// param network - A network id
+// return - A Wallet point
+func CreatePRNGWallet(name, password string, network int) (*Wallet, error) {
+	kp, err := KeyPairCreate("")
+	if err != nil {
+		return nil, err
+	}
+	return newWallet(name, password, kp.PrivateString(), AlgoPRNG, network)
+}
+
+// Create a wallet from a private key
+// param name - The wallet name
+// param password - The password encrypting the private key
+// param privateKey - The private key of the primary account
+// param network - A network id
+// return - A Wallet point
+func ImportPrivateKeyWallet(name, password, privateKey string, network int) (*Wallet, error) {
+	return newWallet(name, password, privateKey, AlgoPrivateKey, network)
+}
+
+// Create a brain wallet, the private key is derived from the passphrase
+// param name - The wallet name
+// param passphrase - The passphrase, also the password of the wallet
+// param network - A network id
+// return - A Wallet point
+func CreateBrainWallet(name, passphrase string, network int) (*Wallet, error) {
+	if name == "" || passphrase == "" {
+		return nil, errors.New("missing parameter !")
+	}
+	kp, err := KeyPairCreate(utils.Bt2Hex(crypto.DerivePassSha(passphrase, brainIterations)))
+	if err != nil {
+		return nil, err
+	}
+	address, err := ToAddress(kp.PublicString(), network)
+	if err != nil {
+		return nil, err
+	}
+	account := WalletAccount{Brain: true, Algo: AlgoBrain, Address: address, Label: "Primary", Network: network}
+	return &Wallet{Name: name, Accounts: map[string]WalletAccount{"0": account}}, nil
+}
+
+func newWallet(name, password, privateKey, algo string, network int) (*Wallet, error) {
+	if name == "" {
+		return nil, errors.New("missing parameter !")
+	}
+	account, err := encryptAccount(password, privateKey, network)
+	if err != nil {
+		return nil, err
+	}
+	account.Brain = algo != AlgoPrivateKey
+	account.Algo = algo
+	account.Label = "Primary"
+	return &Wallet{Name: name, Accounts: map[string]WalletAccount{"0": account}}, nil
+}
+
+func encryptAccount(password, privateKey string, network int) (WalletAccount, error) {
+	kp, err := KeyPairCreate(utils.FixPrivateKey(privateKey))
+	if err != nil {
+		return WalletAccount{}, err
+	}
+	address, err := ToAddress(kp.PublicString(), network)
+	if err != nil {
+		return WalletAccount{}, err
+	}
+	encrypted, iv, err := crypto.EncodePrivKey(privateKey, password)
+	if err != nil {
+		return WalletAccount{}, err
+	}
+	return WalletAccount{Encrypted: encrypted, Iv: iv, Address: address, Network: network}, nil
+}
+
+// Add an account to a wallet, encrypted with the password of the wallet
+// param label - The account label
+// param privateKey - The account private key
+// param password - The password of the wallet
+// return - The index of the account
+func (w *Wallet) AddAccount(label, privateKey, password string) (string, error) {
+	primary, ok := w.Accounts["0"]
+	if !ok {
+		return "", errors.New("wallet has no primary account !")
+	}
+	if _, err := primary.KeyPair(password); err != nil {
+		return "", err
+	}
+	account, err := encryptAccount(password, privateKey, primary.Network)
+	if err != nil {
+		return "", err
+	}
+	account.Brain = primary.Brain
+	account.Algo = primary.Algo
+	account.Label = label
+
+	index := strconv.Itoa(len(w.Accounts))
+	for i := len(w.Accounts) + 1; ; i++ {
+		if _, ok := w.Accounts[index]; !ok {
+			break
+		}
+		index = strconv.Itoa(i)
+	}
+	w.Accounts[index] = account
+	return index, nil
+}
+
+// Decrypt the private key of an account
+// param password - The password of the wallet
+// return - The private key
+func (a WalletAccount) PrivateKey(password string) (string, error) {
+	if password == "" {
+		return "", errors.New("missing parameter !")
+	}
+	var privateKey string
+	switch a.Algo {
+	case AlgoBrain:
+		if a.Encrypted == "" && a.Iv == "" {
+			privateKey = utils.Bt2Hex(crypto.DerivePassSha(password, brainIterations))
+			break
+		}
+		fallthrough
+	case AlgoPRNG, AlgoPrivateKey:
+		if a.Encrypted == "" || a.Iv == "" {
+			return "", errors.New("missing encrypted private key or iv !")
+		}
+		decoded, err := crypto.DecodePrivKey(a.Encrypted, a.Iv, password)
+		if err != nil {
+			return "", ErrWrongPassword
+		}
+		privateKey = utils.FixPrivateKey(decoded)
+	case AlgoTrezor:
+		return "", errors.New("trezor accounts are not supported !")
+	default:
+		return "", errors.New("unknown wallet algorithm " + a.Algo + " !")
+	}
+
+	kp, err := KeyPairCreate(privateKey)
+	if err != nil {
+		return "", err
+	}
+	address, err := ToAddress(kp.PublicString(), a.Network)
+	if err != nil {
+		return "", err
+	}
+	if address != strings.ToUpper(strings.Replace(a.Address, "-", "", -1)) {
+		return "", ErrWrongPassword
+	}
+	return privateKey, nil
+}
+
+// Decrypt the key pair of an account
+// param password - The password of the wallet
+// return - The KeyPair of the account
+func (a WalletAccount) KeyPair(password string) (*KeyPair, error) {
+	privateKey, err := a.PrivateKey(password)
+	if err != nil {
+		return nil, err
+	}
+	return KeyPairCreate(privateKey)
+}
+
+// Gets the account indexes in order, the primary account first
+func (w *Wallet) Indexes() []string {
+	indexes := make([]string, 0, len(w.Accounts))
+	for index := range w.Accounts {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		a, errA := strconv.Atoi(indexes[i])
+		b, errB := strconv.Atoi(indexes[j])
+		if errA != nil || errB != nil {
+			return indexes[i] < indexes[j]
+		}
+		return a < b
+	})
+	return indexes
+}
+
+// Open a wallet, decrypting the key pair of every account
+// param password - The password of the wallet
+// return - The KeyPair of every account, in the order of Indexes
+func (w *Wallet) Open(password string) ([]*KeyPair, error) {
+	indexes := w.Indexes()
+	pairs := make([]*KeyPair, len(indexes))
+	for i, index := range indexes {
+		kp, err := w.Accounts[index].KeyPair(password)
+		if err != nil {
+			return nil, err
+		}
+		pairs[i] = kp
+	}
+	return pairs, nil
+}
+
+// Encode a wallet in the .wlt format, the base64 of its JSON
+// return - The content of a .wlt file
+func (w *Wallet) Encode() (string, error) {
+	data, err := json.Marshal(w)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// Decode a wallet in the .wlt format
+// param wlt - The content of a .wlt file
+// return - A Wallet point
+func DecodeWallet(wlt string) (*Wallet, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(wlt))
+	if err != nil {
+		return nil, err
+	}
+	var w Wallet
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, err
+	}
+	if len(w.Accounts) == 0 {
+		return nil, errors.New("wallet has no account !")
+	}
+	return &w, nil
+}
+
+// Read a .wlt wallet file
+// param path - The file path
+// return - A Wallet point
+func ReadWallet(path string) (*Wallet, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeWallet(string(data))
+}
+
+// Write a wallet to a .wlt file
+// param path - The file path
+func (w *Wallet) Write(path string) error {
+	wlt, err := w.Encode()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(wlt), 0600)
+}
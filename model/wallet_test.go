@@ -0,0 +1,70 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/crypto"
+	"github.com/isarq/nem-sdk-go/utils"
+)
+
+func TestDerivePassSha(t *testing.T) {
+	// The key NanoWallet derives from the password "TestTest"
+	const want = "8cd87bc513857a7079d182a6e19b370e907107d97bd3f81a85bcebcc4b5bd3b5"
+	if got := utils.Bt2Hex(crypto.DerivePassSha("TestTest", 20)); got != want {
+		t.Errorf("DerivePassSha = %s, want %s", got, want)
+	}
+}
+
+func TestWalletRoundTrip(t *testing.T) {
+	const (
+		password   = "TestTest"
+		privateKey = "8fac4fbc0c2d1d0b1d8d10c0e0f1a8b0f8f8e8d8c8b8a8988878685848382810"
+	)
+	w, err := CreatePRNGWallet("test", password, Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	index, err := w.AddAccount("second", privateKey, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index != "1" {
+		t.Errorf("AddAccount index = %s, want 1", index)
+	}
+
+	wlt, err := w.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opened, err := DecodeWallet(wlt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairs, err := opened.Open(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pairs) != 2 || pairs[1].PrivateString() != privateKey {
+		t.Errorf("Open returned %d key pairs, want the imported key second", len(pairs))
+	}
+
+	if _, err := opened.Open("wrong"); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("Open with a wrong password: err = %v, want ErrWrongPassword", err)
+	}
+}
+
+func TestBrainWallet(t *testing.T) {
+	w, err := CreateBrainWallet("brain", "correct horse battery staple", Data.Testnet.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kp, err := w.Accounts["0"].KeyPair("correct horse battery staple")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := utils.Bt2Hex(crypto.DerivePassSha("correct horse battery staple", 6000))
+	if kp.PrivateString() != want {
+		t.Errorf("brain private key = %s, want %s", kp.PrivateString(), want)
+	}
+}
@@ -0,0 +1,608 @@
+package utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/isarq/nem-sdk-go/base"
+)
+
+var (
+	// ErrTruncated is returned when a serialized transaction ends before all of its fields are read
+	ErrTruncated = errors.New("serialized transaction is truncated")
+	// ErrMalformed is returned when a serialized transaction holds an inconsistent field
+	ErrMalformed = errors.New("serialized transaction is malformed")
+	// ErrUnknownType is returned when a serialized transaction has an unsupported type
+	ErrUnknownType = errors.New("unknown transaction type")
+)
+
+// DeserializeError describes the field of a serialized transaction that could not be read.
+// The wrapped error is one of ErrTruncated, ErrMalformed or ErrUnknownType.
+type DeserializeError struct {
+	Field  string
+	Offset int
+	Err    error
+}
+
+func (e *DeserializeError) Error() string {
+	return fmt.Sprintf("deserialize %s at offset %d: %v", e.Field, e.Offset, e.Err)
+}
+
+func (e *DeserializeError) Unwrap() error {
+	return e.Err
+}
+
+// nullString is the length written by serializeSafeString for an empty string
+const nullString = 0xffffffff
+
+type deserializer struct {
+	data   []byte
+	offset int
+	// base is the offset of data inside the top level payload, used for error reporting
+	base int
+}
+
+func (d *deserializer) fail(field string, err error) error {
+	return &DeserializeError{Field: field, Offset: d.base + d.offset, Err: err}
+}
+
+func (d *deserializer) next(field string, n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.offset < n {
+		return nil, d.fail(field, ErrTruncated)
+	}
+	b := d.data[d.offset : d.offset+n]
+	d.offset += n
+	return b, nil
+}
+
+func (d *deserializer) uint32(field string) (uint32, error) {
+	b, err := d.next(field, 4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func (d *deserializer) uint64(field string) (uint64, error) {
+	b, err := d.next(field, 8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+// fixed reads a length prefixed field whose length must be equal to size
+func (d *deserializer) fixed(field string, size int) ([]byte, error) {
+	l, err := d.uint32(field)
+	if err != nil {
+		return nil, err
+	}
+	if int(l) != size {
+		d.offset -= 4
+		return nil, d.fail(field, ErrMalformed)
+	}
+	return d.next(field, size)
+}
+
+// lengthPrefixed reads a length prefixed field. The null length is reported with ok false.
+func (d *deserializer) lengthPrefixed(field string) (b []byte, ok bool, err error) {
+	l, err := d.uint32(field)
+	if err != nil {
+		return nil, false, err
+	}
+	if l == nullString {
+		return nil, false, nil
+	}
+	if uint64(l) > uint64(len(d.data)-d.offset) {
+		d.offset -= 4
+		return nil, false, d.fail(field, ErrTruncated)
+	}
+	b, err = d.next(field, int(l))
+	return b, true, err
+}
+
+func (d *deserializer) safeString(field string) (string, error) {
+	b, _, err := d.lengthPrefixed(field)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (d *deserializer) hexKey(field string) (string, error) {
+	b, err := d.fixed(field, Const4bytessigner)
+	if err != nil {
+		return "", err
+	}
+	return Bt2Hex(b), nil
+}
+
+func (d *deserializer) address(field string) (string, error) {
+	b, err := d.fixed(field, Const4bytesaddress)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// structure reads a length prefixed structure and returns a deserializer bound to its content
+func (d *deserializer) structure(field string) (*deserializer, error) {
+	start := d.base + d.offset + 4
+	b, ok, err := d.lengthPrefixed(field)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		d.offset -= 4
+		return nil, d.fail(field, ErrMalformed)
+	}
+	return &deserializer{data: b, base: start}, nil
+}
+
+// end checks that every byte of the structure was consumed
+func (d *deserializer) end(field string) error {
+	if d.offset != len(d.data) {
+		return d.fail(field, ErrMalformed)
+	}
+	return nil
+}
+
+func (d *deserializer) mosaicId(field string) (base.MosaicID, error) {
+	s, err := d.structure(field)
+	if err != nil {
+		return base.MosaicID{}, err
+	}
+	namespaceId, err := s.safeString(field + ".namespaceId")
+	if err != nil {
+		return base.MosaicID{}, err
+	}
+	name, err := s.safeString(field + ".name")
+	if err != nil {
+		return base.MosaicID{}, err
+	}
+	if err := s.end(field); err != nil {
+		return base.MosaicID{}, err
+	}
+	return base.MosaicID{NamespaceID: namespaceId, Name: name}, nil
+}
+
+// DeserializeTransaction parses a serialized transaction, as produced by SerializeTransaction,
+// back into its transaction struct
+// param data - The serialized transaction
+// return - A transaction struct
+func DeserializeTransaction(data []byte) (base.Transaction, error) {
+	d := &deserializer{data: data}
+	tx, err := d.transaction()
+	if err != nil {
+		return nil, err
+	}
+	if err := d.end("transaction"); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (d *deserializer) transaction() (base.Transaction, error) {
+	common, err := d.commonHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	switch common.Type {
+	case Transfer:
+		return d.transferTransaction(common)
+	case ImportanceTransfer:
+		return d.importanceTransferTransaction(common)
+	case MultisigModification:
+		return d.multisigAggregateModificationTransaction(common)
+	case MultisigSignature:
+		return d.multisigSignatureTransaction(common)
+	case MultiSignTransaction:
+		return d.multiSignTransaction(common)
+	case ProvisionNamespace:
+		return d.provisionNamespaceTransaction(common)
+	case Mosaicdefinition:
+		return d.mosaicDefinitionCreationTransaction(common)
+	case MosaicSupply:
+		return d.mosaicSupplyChangeTransaction(common)
+	}
+	return nil, &DeserializeError{Field: "type", Offset: d.base, Err: ErrUnknownType}
+}
+
+func (d *deserializer) commonHeader() (base.CommonTransaction, error) {
+	var common base.CommonTransaction
+	txType, err := d.uint32("type")
+	if err != nil {
+		return common, err
+	}
+	version, err := d.uint32("version")
+	if err != nil {
+		return common, err
+	}
+	timeStamp, err := d.uint32("timeStamp")
+	if err != nil {
+		return common, err
+	}
+	signer, err := d.hexKey("signer")
+	if err != nil {
+		return common, err
+	}
+	fee, err := d.uint64("fee")
+	if err != nil {
+		return common, err
+	}
+	deadline, err := d.uint32("deadline")
+	if err != nil {
+		return common, err
+	}
+	ts := int64(timeStamp)
+	dl := int64(deadline)
+	common.Type = int(txType)
+	common.Version = int(version)
+	common.TimeStamp = &ts
+	common.Signer = signer
+	common.Fee = base.Amount(fee)
+	common.Deadline = &dl
+	return common, nil
+}
+
+func (d *deserializer) transferTransaction(common base.CommonTransaction) (base.Transaction, error) {
+	tx := &base.TransferTransaction{CommonTransaction: common}
+	recipient, err := d.address("recipient")
+	if err != nil {
+		return nil, err
+	}
+	tx.Recipient = recipient
+
+	amount, err := d.uint64("amount")
+	if err != nil {
+		return nil, err
+	}
+	tx.Amount = base.Amount(amount)
+
+	msgLength, err := d.uint32("message")
+	if err != nil {
+		return nil, err
+	}
+	if msgLength != 0 {
+		d.offset -= 4
+		s, err := d.structure("message")
+		if err != nil {
+			return nil, err
+		}
+		msgType, err := s.uint32("message.type")
+		if err != nil {
+			return nil, err
+		}
+		payload, _, err := s.lengthPrefixed("message.payload")
+		if err != nil {
+			return nil, err
+		}
+		if err := s.end("message"); err != nil {
+			return nil, err
+		}
+		tx.Message = base.Message{
+			Type:    int(msgType),
+			Payload: Bt2Hex(payload),
+		}
+	}
+
+	if tx.Version&0xffffff >= 2 {
+		count, err := d.uint32("mosaics")
+		if err != nil {
+			return nil, err
+		}
+		for i := uint32(0); i < count; i++ {
+			field := fmt.Sprintf("mosaics[%d]", i)
+			s, err := d.structure(field)
+			if err != nil {
+				return nil, err
+			}
+			mosaicId, err := s.mosaicId(field + ".mosaicId")
+			if err != nil {
+				return nil, err
+			}
+			quantity, err := s.uint64(field + ".quantity")
+			if err != nil {
+				return nil, err
+			}
+			if err := s.end(field); err != nil {
+				return nil, err
+			}
+			tx.Mosaics = append(tx.Mosaics, base.Mosaic{
+				MosaicID: mosaicId,
+				Quantity: base.Quantity(quantity),
+			})
+		}
+	}
+	return tx, nil
+}
+
+func (d *deserializer) importanceTransferTransaction(common base.CommonTransaction) (base.Transaction, error) {
+	tx := &base.ImportanceTransferTransaction{CommonTransaction: common}
+	mode, err := d.uint32("mode")
+	if err != nil {
+		return nil, err
+	}
+	if mode != 1 && mode != 2 {
+		d.offset -= 4
+		return nil, d.fail("mode", ErrMalformed)
+	}
+	tx.Mode = int(mode)
+
+	remoteAccount, err := d.hexKey("remoteAccount")
+	if err != nil {
+		return nil, err
+	}
+	tx.RemoteAccount = remoteAccount
+	return tx, nil
+}
+
+func (d *deserializer) multisigAggregateModificationTransaction(common base.CommonTransaction) (base.Transaction, error) {
+	tx := &base.MultisigAggregateModificationTransaction{CommonTransaction: common}
+	count, err := d.uint32("modifications")
+	if err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < count; i++ {
+		field := fmt.Sprintf("modifications[%d]", i)
+		s, err := d.structure(field)
+		if err != nil {
+			return nil, err
+		}
+		modificationType, err := s.uint32(field + ".modificationType")
+		if err != nil {
+			return nil, err
+		}
+		cosignatory, err := s.hexKey(field + ".cosignatoryAccount")
+		if err != nil {
+			return nil, err
+		}
+		if err := s.end(field); err != nil {
+			return nil, err
+		}
+		tx.Modifications = append(tx.Modifications, base.ConsModif{
+			ModificationType:   int(modificationType),
+			CosignatoryAccount: cosignatory,
+		})
+	}
+
+	if tx.Version&0xffffff >= 2 {
+		length, err := d.uint32("minCosignatories")
+		if err != nil {
+			return nil, err
+		}
+		if length != 0 {
+			d.offset -= 4
+			s, err := d.structure("minCosignatories")
+			if err != nil {
+				return nil, err
+			}
+			relativeChange, err := s.uint32("minCosignatories.relativeChange")
+			if err != nil {
+				return nil, err
+			}
+			if err := s.end("minCosignatories"); err != nil {
+				return nil, err
+			}
+			tx.MinCosignatories = &base.MinCosignatories{RelativeChange: int(int32(relativeChange))}
+		}
+	}
+	return tx, nil
+}
+
+func (d *deserializer) multisigSignatureTransaction(common base.CommonTransaction) (base.Transaction, error) {
+	tx := &base.MultisigSignatureTransaction{CommonTransaction: common}
+	s, err := d.structure("otherHash")
+	if err != nil {
+		return nil, err
+	}
+	hash, err := s.fixed("otherHash.data", 32)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.end("otherHash"); err != nil {
+		return nil, err
+	}
+	tx.OtherHash.Data = Bt2Hex(hash)
+
+	otherAccount, err := d.address("otherAccount")
+	if err != nil {
+		return nil, err
+	}
+	tx.OtherAccount = otherAccount
+	return tx, nil
+}
+
+func (d *deserializer) multiSignTransaction(common base.CommonTransaction) (base.Transaction, error) {
+	s, err := d.structure("otherTrans")
+	if err != nil {
+		return nil, err
+	}
+	inner, err := s.transaction()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.end("otherTrans"); err != nil {
+		return nil, err
+	}
+	if inner.GetType() == MultiSignTransaction {
+		return nil, &DeserializeError{Field: "otherTrans", Offset: s.base, Err: ErrMalformed}
+	}
+	return &base.MultiSignTransaction{
+		CommonTransaction: common,
+		OtherTrans:        inner,
+	}, nil
+}
+
+func (d *deserializer) provisionNamespaceTransaction(common base.CommonTransaction) (base.Transaction, error) {
+	tx := &base.ProvisionNamespaceTransaction{CommonTransaction: common}
+	rentalFeeSink, err := d.address("rentalFeeSink")
+	if err != nil {
+		return nil, err
+	}
+	tx.RentalFeeSink = rentalFeeSink
+
+	rentalFee, err := d.uint64("rentalFee")
+	if err != nil {
+		return nil, err
+	}
+	tx.RentalFee = base.Amount(rentalFee)
+
+	newPart, err := d.safeString("newPart")
+	if err != nil {
+		return nil, err
+	}
+	tx.NewPart = newPart
+
+	parent, err := d.safeString("parent")
+	if err != nil {
+		return nil, err
+	}
+	tx.Parent = parent
+	return tx, nil
+}
+
+func (d *deserializer) mosaicDefinitionCreationTransaction(common base.CommonTransaction) (base.Transaction, error) {
+	tx := &base.MosaicDefinitionCreationTransaction{CommonTransaction: common}
+	s, err := d.structure("mosaicDefinition")
+	if err != nil {
+		return nil, err
+	}
+	definition, err := s.mosaicDefinition()
+	if err != nil {
+		return nil, err
+	}
+	tx.MosaicDefinition = definition
+
+	creationFeeSink, err := d.address("creationFeeSink")
+	if err != nil {
+		return nil, err
+	}
+	tx.CreationFeeSink = creationFeeSink
+
+	creationFee, err := d.uint64("creationFee")
+	if err != nil {
+		return nil, err
+	}
+	tx.CreationFee = base.Amount(creationFee)
+	return tx, nil
+}
+
+func (d *deserializer) mosaicDefinition() (base.MosaicDefinition, error) {
+	var definition base.MosaicDefinition
+	creator, err := d.hexKey("mosaicDefinition.creator")
+	if err != nil {
+		return definition, err
+	}
+	definition.Creator = creator
+
+	id, err := d.mosaicId("mosaicDefinition.id")
+	if err != nil {
+		return definition, err
+	}
+	definition.ID = id
+
+	description, err := d.safeString("mosaicDefinition.description")
+	if err != nil {
+		return definition, err
+	}
+	definition.Description = description
+
+	count, err := d.uint32("mosaicDefinition.properties")
+	if err != nil {
+		return definition, err
+	}
+	for i := uint32(0); i < count; i++ {
+		field := fmt.Sprintf("mosaicDefinition.properties[%d]", i)
+		s, err := d.structure(field)
+		if err != nil {
+			return definition, err
+		}
+		name, err := s.safeString(field + ".name")
+		if err != nil {
+			return definition, err
+		}
+		value, err := s.safeString(field + ".value")
+		if err != nil {
+			return definition, err
+		}
+		if err := s.end(field); err != nil {
+			return definition, err
+		}
+		definition.Properties = append(definition.Properties, base.Properties{Name: name, Value: value})
+	}
+
+	length, err := d.uint32("mosaicDefinition.levy")
+	if err != nil {
+		return definition, err
+	}
+	if length != 0 {
+		d.offset -= 4
+		s, err := d.structure("mosaicDefinition.levy")
+		if err != nil {
+			return definition, err
+		}
+		levy, err := s.levy()
+		if err != nil {
+			return definition, err
+		}
+		definition.Levy = levy
+	}
+	return definition, d.end("mosaicDefinition")
+}
+
+func (d *deserializer) levy() (base.Levy, error) {
+	var levy base.Levy
+	feeType, err := d.uint32("levy.type")
+	if err != nil {
+		return levy, err
+	}
+	levy.Type = int(feeType)
+
+	recipient, err := d.address("levy.recipient")
+	if err != nil {
+		return levy, err
+	}
+	levy.Recipient = recipient
+
+	mosaicId, err := d.mosaicId("levy.mosaicId")
+	if err != nil {
+		return levy, err
+	}
+	levy.MosaicID = mosaicId
+
+	fee, err := d.uint64("levy.fee")
+	if err != nil {
+		return levy, err
+	}
+	levy.Fee = base.Amount(fee)
+	return levy, d.end("levy")
+}
+
+func (d *deserializer) mosaicSupplyChangeTransaction(common base.CommonTransaction) (base.Transaction, error) {
+	tx := &base.MosaicSupplyChangeTransaction{CommonTransaction: common}
+	mosaicId, err := d.mosaicId("mosaicId")
+	if err != nil {
+		return nil, err
+	}
+	tx.MosaicID = mosaicId
+
+	supplyType, err := d.uint32("supplyType")
+	if err != nil {
+		return nil, err
+	}
+	if supplyType != 1 && supplyType != 2 {
+		d.offset -= 4
+		return nil, d.fail("supplyType", ErrMalformed)
+	}
+	tx.SupplyType = int(supplyType)
+
+	delta, err := d.uint64("delta")
+	if err != nil {
+		return nil, err
+	}
+	tx.Delta = delta
+	return tx, nil
+}
@@ -0,0 +1,185 @@
+package utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/base"
+)
+
+const testAddress = "TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S"
+
+// One transaction of every type
+func deserializeVectors() map[string]base.Transaction {
+	xem := base.MosaicID{NamespaceID: "nem", Name: "xem"}
+	definition := base.MosaicDefinition{
+		Creator:     testSigner,
+		ID:          base.MosaicID{NamespaceID: "foo", Name: "bar"},
+		Description: "a mosaic",
+		Properties: []base.Properties{
+			{Name: "divisibility", Value: "3"},
+			{Name: "initialSupply", Value: "1000"},
+			{Name: "supplyMutable", Value: "true"},
+			{Name: "transferable", Value: "true"},
+		},
+	}
+	levied := definition
+	levied.Levy = base.Levy{Type: 1, Recipient: testAddress, MosaicID: xem, Fee: 5}
+	modification := &base.MultisigAggregateModificationTransaction{
+		CommonTransaction: testCommon(MultisigModification, 0x98000002, 500000),
+		Modifications: []base.ConsModif{
+			{ModificationType: 1, CosignatoryAccount: testSigner},
+			{ModificationType: 2, CosignatoryAccount: "a1aaca6c17a24252e674d155713cdf55996ad00175be4af02a20c67b59f9fe8a"},
+		},
+		MinCosignatories: &base.MinCosignatories{RelativeChange: -1},
+	}
+	signature := &base.MultisigSignatureTransaction{
+		CommonTransaction: testCommon(MultisigSignature, 0x98000001, 150000),
+		OtherAccount:      testAddress,
+	}
+	signature.OtherHash.Data = "e1f7b2e1dd6e1f5ad8a4c34e1bc1ba4b41def2b8d44b6b0e5bd7d82a3b6a6a54"
+
+	return map[string]base.Transaction{
+		"transfer": &base.TransferTransaction{
+			CommonTransaction: testCommon(Transfer, 0x98000001, 50000),
+			Amount:            5000000,
+			Recipient:         testAddress,
+			Message:           base.Message{Type: 1, Payload: "48656c6c6f"},
+		},
+		"mosaic transfer": &base.TransferTransaction{
+			CommonTransaction: testCommon(Transfer, 0x98000002, 50000),
+			Amount:            1000000,
+			Recipient:         testAddress,
+			// Sorted by name, as serialized
+			Mosaics: []base.Mosaic{{MosaicID: definition.ID, Quantity: 1 << 40}, {MosaicID: xem, Quantity: 1}},
+		},
+		"importance transfer": &base.ImportanceTransferTransaction{
+			CommonTransaction: testCommon(ImportanceTransfer, 0x98000001, 150000),
+			Mode:              2,
+			RemoteAccount:     testSigner,
+		},
+		"multisig modification": modification,
+		"multisig signature":    signature,
+		"multisig": &base.MultiSignTransaction{
+			CommonTransaction: testCommon(MultiSignTransaction, 0x98000001, 150000),
+			OtherTrans:        modification,
+		},
+		"root namespace": &base.ProvisionNamespaceTransaction{
+			CommonTransaction: testCommon(ProvisionNamespace, 0x98000001, 150000),
+			RentalFeeSink:     testAddress,
+			RentalFee:         100 * base.XEM,
+			NewPart:           "foo",
+		},
+		"sub namespace": &base.ProvisionNamespaceTransaction{
+			CommonTransaction: testCommon(ProvisionNamespace, 0x98000001, 150000),
+			RentalFeeSink:     testAddress,
+			RentalFee:         10 * base.XEM,
+			NewPart:           "bar",
+			Parent:            "foo",
+		},
+		"mosaic definition": &base.MosaicDefinitionCreationTransaction{
+			CommonTransaction: testCommon(Mosaicdefinition, 0x98000001, 150000),
+			CreationFee:       10 * base.XEM,
+			CreationFeeSink:   testAddress,
+			MosaicDefinition:  definition,
+		},
+		"mosaic definition with levy": &base.MosaicDefinitionCreationTransaction{
+			CommonTransaction: testCommon(Mosaicdefinition, 0x98000001, 150000),
+			CreationFee:       10 * base.XEM,
+			CreationFeeSink:   testAddress,
+			MosaicDefinition:  levied,
+		},
+		"mosaic supply change": &base.MosaicSupplyChangeTransaction{
+			CommonTransaction: testCommon(MosaicSupply, 0x98000001, 150000),
+			MosaicID:          definition.ID,
+			SupplyType:        2,
+			Delta:             1000,
+		},
+	}
+}
+
+// Deserialize without letting a panic escape
+func deserialize(t *testing.T, data []byte) (tx base.Transaction, err error) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("DeserializeTransaction(%x) panicked: %v", data, r)
+		}
+	}()
+	return DeserializeTransaction(data)
+}
+
+func TestDeserializeTransaction(t *testing.T) {
+	for name, want := range deserializeVectors() {
+		data := SerializeTransaction(want)
+		if len(data) == 0 {
+			t.Fatalf("%s: not serialized", name)
+		}
+		got, err := deserialize(t, data)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: DeserializeTransaction =\n%#v\nwant\n%#v", name, got, want)
+		}
+	}
+}
+
+func TestDeserializeTruncated(t *testing.T) {
+	for name, tx := range deserializeVectors() {
+		data := SerializeTransaction(tx)
+		for n := 0; n < len(data); n++ {
+			_, err := deserialize(t, data[:n])
+			if !errors.Is(err, ErrTruncated) && !errors.Is(err, ErrMalformed) {
+				t.Fatalf("%s: %d of %d bytes: err = %v, want ErrTruncated", name, n, len(data), err)
+			}
+		}
+	}
+}
+
+func TestDeserializeOversized(t *testing.T) {
+	for name, tx := range deserializeVectors() {
+		data := append(SerializeTransaction(tx), 0)
+		if _, err := deserialize(t, data); !errors.Is(err, ErrMalformed) {
+			t.Errorf("%s: trailing byte: err = %v, want ErrMalformed", name, err)
+		}
+	}
+
+	// A message length larger than the transaction
+	data := SerializeTransaction(deserializeVectors()["transfer"])
+	binary.LittleEndian.PutUint32(data[len(data)-17:], 0xfffffff0)
+	if _, err := deserialize(t, data); err == nil {
+		t.Error("oversized message length: no error")
+	}
+}
+
+func TestDeserializeUnknownType(t *testing.T) {
+	data := SerializeTransaction(deserializeVectors()["transfer"])
+	binary.LittleEndian.PutUint32(data, 0x9999)
+	_, err := deserialize(t, data)
+	var deserializeErr *DeserializeError
+	if !errors.Is(err, ErrUnknownType) || !errors.As(err, &deserializeErr) || deserializeErr.Field != "type" {
+		t.Errorf("err = %v, want ErrUnknownType", err)
+	}
+}
+
+// Random corruptions are reported as errors, never as panics
+func TestDeserializeCorrupted(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for name, tx := range deserializeVectors() {
+		data := SerializeTransaction(tx)
+		for i := 0; i < 2000; i++ {
+			corrupted := append([]byte(nil), data...)
+			for j := 0; j < 1+r.Intn(4); j++ {
+				corrupted[r.Intn(len(corrupted))] = byte(r.Intn(256))
+			}
+			if got, err := deserialize(t, corrupted); err == nil && got == nil {
+				t.Fatalf("%s: no transaction and no error", name)
+			}
+		}
+	}
+}
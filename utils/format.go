@@ -1,18 +1,39 @@
 package utils
 
 import (
+	"encoding/base32"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/isarq/nem-sdk-go/base"
+	"github.com/isarq/nem-sdk-go/external/crypto/sha3"
 	"github.com/isarq/nem-sdk-go/extras"
+	"golang.org/x/crypto/ripemd160"
+	"strings"
 )
 
 // Convert a public key to NEM address
-// param input - The account public key
+// param publicKey - The account public key
 // param networkId - The current network id
 // return - A clean NEM address
-func PubToAddress() {
+func PubToAddress(publicKey string, networkId int) (base.Address, error) {
+	pk, err := hex.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil {
+		return "", err
+	}
+	if len(pk) != 32 {
+		return "", errors.New("Invalid public key !")
+	}
+
+	h := sha3.SumKeccak256(pk)
+	md := ripemd160.New()
+	md.Write(h[:])
+
+	s := append([]byte{base.NetworkPrefix(networkId)}, md.Sum(nil)...)
+	h = sha3.SumKeccak256(s)
 
+	return base.Address(base32.StdEncoding.EncodeToString(append(s, h[:4]...))), nil
 }
 
 func Struc2Json(data interface{}) string {
@@ -87,6 +87,13 @@ func CreateNEMTimeStamp() int64 {
 	return int64(math.Floor(float64(time.Now().Unix() - 1427587585)))
 }
 
+// Convert a time to a NEM time stamp
+// param t - A time
+// return - The seconds elapsed since the NEM epoch
+func ToNEMTimeStamp(t time.Time) int64 {
+	return t.Unix() - 1427587585
+}
+
 // Fix a private key
 // param privatekey - An hex private key
 // return - The fixed hex private key
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"github.com/isarq/nem-sdk-go/base"
 	"github.com/isarq/nem-sdk-go/extras"
-	"math"
 	"sort"
 )
 
@@ -20,9 +19,24 @@ type Temporary struct {
 	value  string
 }
 
+// The transfer transaction type
+const Transfer = 0x101 // 257
+
+// The importance transfer type
+const ImportanceTransfer = 0x801 // 2049
+
+// The aggregate modification transaction type
+const MultisigModification = 0x1001 // 4097
+
+// The multisignature transaction type
+const MultiSignTransaction = 0x1004 // 4100
+
 // The mosaic definition transaction type
 const Mosaicdefinition = 0x4001 // 16385
 
+// The mosaic supply change transaction type
+const MosaicSupply = 0x4002 // 16386
+
 // The provision namespace transaction type
 const ProvisionNamespace = 0x2001 // 8193
 
@@ -54,11 +68,9 @@ func serializeUaString(str []byte) []byte {
 	return data
 }
 
-func serializeLong(value float64) []byte {
-	var data []byte
-	data = append(data, encodeByte4(value)...)
-	data = append(data, encodeByte4(math.Floor(value/0x100000000))...)
-	return data
+// long - 8 bytes little-endian unsigned integer
+func serializeLong(value uint64) []byte {
+	return encodeByte8(value)
 }
 
 // Mosaic id structure
@@ -79,7 +91,7 @@ func serializeMosaicAndQuantity(mosaic base.Mosaic) []byte {
 	var data []byte
 
 	serializedMosaicId := serializeMosaicId(mosaic.MosaicID)
-	serializedQuantity := serializeLong(mosaic.Quantity)
+	serializedQuantity := serializeLong(uint64(mosaic.Quantity))
 
 	data = append(data, encodeByte4(len(serializedMosaicId)+len(serializedQuantity))...)
 	data = append(data, serializedMosaicId...)
@@ -157,7 +169,7 @@ func serializeLevy(entity base.Levy) []byte {
 	// Example: 0x10, 0x00, 0x00, 0x00
 	serializedMosaicId := serializeMosaicId(entity.MosaicID)
 
-	serializedFee := serializeLong(entity.Fee)
+	serializedFee := serializeLong(uint64(entity.Fee))
 
 	// Length of levy structure: 4 bytes (integer).
 	// Example: 0x4c, 0x00, 0x00, 0x00
@@ -235,8 +247,7 @@ func SerializeTransaction(entity interface{}) []byte {
 
 		data = append(data, []byte(tx.Recipient)...) //signer 40 bytes
 
-		data = append(data, encodeByte4(tx.Amount)...)                         //amount 0x40420f0000000000
-		data = append(data, encodeByte4(math.Floor(tx.Amount/0x100000000))...) //amount 0x40420f0000000000
+		data = append(data, encodeByte8(tx.Amount)...) //amount 0x40420f0000000000
 
 		if !extras.IsEmpty(tx.Message.Payload) && len(tx.Message.Payload) > 0 {
 			msglength := len(tx.Message.Payload) / 2
@@ -278,7 +289,7 @@ func SerializeTransaction(entity interface{}) []byte {
 		temp = serializeSafeString(s.CreationFeeSink)
 		data = append(data, temp...)
 
-		temp = serializeLong(s.CreationFee)
+		temp = serializeLong(uint64(s.CreationFee))
 		data = append(data, temp...)
 
 		// Provision Namespace transaction
@@ -293,8 +304,7 @@ func SerializeTransaction(entity interface{}) []byte {
 		// TODO: check that len(entity.RentalFee) is always 40 bytes
 		data = append(data, tx.RentalFeeSink...)
 
-		data = append(data, encodeByte4(tx.RentalFee)...)
-		data = append(data, encodeByte4(math.Floor(tx.RentalFee/0x100000000))...)
+		data = append(data, serializeLong(uint64(tx.RentalFee))...)
 
 		temp := serializeSafeString(tx.NewPart)
 		data = append(data, temp...)
@@ -302,6 +312,75 @@ func SerializeTransaction(entity interface{}) []byte {
 		temp = serializeSafeString(tx.Parent)
 		data = append(data, temp...)
 
+		// Mosaic supply change transaction
+	case *base.MosaicSupplyChangeTransaction:
+		tx, _ := entity.(*base.MosaicSupplyChangeTransaction)
+		common, _ := commonHeader(tx)
+		data = common
+
+		temp := serializeMosaicId(tx.MosaicID)
+		data = append(data, temp...)
+
+		data = append(data, encodeByte4(tx.SupplyType)...)
+
+		temp = serializeLong(tx.Delta)
+		data = append(data, temp...)
+
+		// Importance transfer transaction
+	case *base.ImportanceTransferTransaction:
+		tx, _ := entity.(*base.ImportanceTransferTransaction)
+		common, _ := commonHeader(tx)
+		data = common
+
+		data = append(data, encodeByte4(tx.Mode)...)
+
+		RemoteAccount, _ := hex.DecodeString(tx.RemoteAccount)
+		data = append(data, encodeByte4(len(RemoteAccount))...)
+		data = append(data, RemoteAccount...)
+
+		// Multisig aggregate modification transaction
+	case *base.MultisigAggregateModificationTransaction:
+		tx, _ := entity.(*base.MultisigAggregateModificationTransaction)
+		common, _ := commonHeader(tx)
+		data = common
+
+		data = append(data, encodeByte4(len(tx.Modifications))...)
+		for _, m := range tx.Modifications {
+			CosignatoryAccount, _ := hex.DecodeString(m.CosignatoryAccount)
+			// Length of cosignatory modification structure: 4 bytes (integer).
+			// Always: 0x28, 0x00, 0x00, 0x00
+			data = append(data, encodeByte4(4+4+len(CosignatoryAccount))...)
+			data = append(data, encodeByte4(m.ModificationType)...)
+			data = append(data, encodeByte4(len(CosignatoryAccount))...)
+			data = append(data, CosignatoryAccount...)
+		}
+
+		// Minimum cosignatories modification, only from version 2
+		if tx.Version&0xffffff >= 2 {
+			if tx.MinCosignatories != nil && tx.MinCosignatories.RelativeChange != 0 {
+				data = append(data, encodeByte4(4)...)
+				data = append(data, encodeByte4(tx.MinCosignatories.RelativeChange)...)
+			} else {
+				data = append(data, encodeByte4(0)...)
+			}
+		}
+
+		// Multisig signature transaction
+	case *base.MultisigSignatureTransaction:
+		tx, _ := entity.(*base.MultisigSignatureTransaction)
+		common, _ := commonHeader(tx)
+		data = common
+
+		OtherHash, _ := hex.DecodeString(tx.OtherHash.Data)
+		// Length of hash object: 4 bytes (integer). Always: 0x24, 0x00, 0x00, 0x00
+		data = append(data, encodeByte4(4+len(OtherHash))...)
+		data = append(data, encodeByte4(len(OtherHash))...)
+		data = append(data, OtherHash...)
+
+		OtherAccount := []byte(tx.OtherAccount)
+		data = append(data, encodeByte4(len(OtherAccount))...)
+		data = append(data, OtherAccount...)
+
 		// MultiSign wrapped transaction
 	case *base.MultiSignTransaction:
 		//fmt.Println("MultiSignSignature")
@@ -333,32 +412,43 @@ func commonHeader(txstruct base.Transaction) (ch []byte, err error) {
 	return data, nil
 }
 
+// Encode a 32 bits integer in 4 bytes little-endian, negative values in two's complement
+// The types are fixed by the transaction structs, an unsupported type is a bug and panics as in encodeByte8
 func encodeByte4(valor interface{}) []byte {
 	var Type = make([]byte, 4)
 
-	if s, ok := valor.(int); ok {
+	switch s := valor.(type) {
+	case int:
 		binary.LittleEndian.PutUint32(Type, uint32(s))
-	}
-	if s, ok := valor.(int64); ok {
+	case int32:
 		binary.LittleEndian.PutUint32(Type, uint32(s))
-	}
-	if s, ok := valor.(float64); ok {
+	case int64:
+		binary.LittleEndian.PutUint32(Type, uint32(s))
+	case uint32:
+		binary.LittleEndian.PutUint32(Type, s)
+	case float64:
 		binary.LittleEndian.PutUint32(Type, uint32(s))
+	default:
+		panic(fmt.Sprintf("encodeByte4: unsupported type %T", valor))
 	}
 	return Type
 }
 
+// Encode a 64 bits integer in 8 bytes little-endian, negative values in two's complement
 func encodeByte8(valor interface{}) []byte {
 	var Type = make([]byte, 8)
 
-	if s, ok := valor.(int); ok {
-		binary.LittleEndian.PutUint32(Type, uint32(s))
-	}
-	if s, ok := valor.(int64); ok {
-		binary.LittleEndian.PutUint32(Type, uint32(s))
-	}
-	if s, ok := valor.(float64); ok {
-		binary.LittleEndian.PutUint32(Type, uint32(s))
+	switch s := valor.(type) {
+	case int:
+		binary.LittleEndian.PutUint64(Type, uint64(s))
+	case int64:
+		binary.LittleEndian.PutUint64(Type, uint64(s))
+	case uint64:
+		binary.LittleEndian.PutUint64(Type, s)
+	case base.Amount:
+		binary.LittleEndian.PutUint64(Type, uint64(s))
+	default:
+		panic(fmt.Sprintf("encodeByte8: unsupported type %T", valor))
 	}
 	return Type
 }
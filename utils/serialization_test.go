@@ -0,0 +1,227 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/isarq/nem-sdk-go/base"
+)
+
+const testSigner = "5f06cd912b6e0a6e7a0a290aa83c82c417c0e6700b751385efca1f060b7a9a9f"
+
+func testCommon(txType, version int, fee base.Amount) base.CommonTransaction {
+	timeStamp, deadline := int64(86200000), int64(86203600)
+	return base.CommonTransaction{
+		Type:      txType,
+		Version:   version,
+		Signer:    testSigner,
+		TimeStamp: &timeStamp,
+		Fee:       fee,
+		Deadline:  &deadline,
+	}
+}
+
+func TestEncodeByte4(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{0, "00000000"},
+		{0x1001, "01100000"},
+		{int64(86200000), "c04e2305"},
+		{uint32(0xffffffff), "ffffffff"},
+		{int32(-1), "ffffffff"},
+		{-1, "ffffffff"},
+		{float64(40), "28000000"},
+	}
+	for _, tt := range tests {
+		if got := hex.EncodeToString(encodeByte4(tt.value)); got != tt.want {
+			t.Errorf("encodeByte4(%v) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+// An unsupported type is never encoded as 0
+func TestEncodeUnsupported(t *testing.T) {
+	for name, encode := range map[string]func(interface{}) []byte{"encodeByte4": encodeByte4, "encodeByte8": encodeByte8} {
+		for _, value := range []interface{}{"1", uint16(1), nil} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("%s(%#v) did not panic", name, value)
+					}
+				}()
+				encode(value)
+			}()
+		}
+	}
+}
+
+func TestEncodeByte8(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{0, "0000000000000000"},
+		{base.Amount(100000), "a086010000000000"},
+		{base.Amount(1000000), "40420f0000000000"},
+		{uint64(1 << 32), "0000000001000000"},
+		{base.Amount(4295067296), "a086010001000000"},
+		{int64(1 << 62), "0000000000000040"},
+		{uint64(1<<64 - 1), "ffffffffffffffff"},
+		{-1, "ffffffffffffffff"},
+	}
+	for _, tt := range tests {
+		if got := hex.EncodeToString(encodeByte8(tt.value)); got != tt.want {
+			t.Errorf("encodeByte8(%v) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+// Golden vectors built field by field from the NIS binary layout, the same bytes as an independent encoder
+// link http://bob.nem.ninja/docs/#transaction-objects
+func TestSerializeTransactionGolden(t *testing.T) {
+	tests := []struct {
+		name string
+		tx   base.Transaction
+		want string
+	}{
+		{
+			// Fee and amount above 2^32 micro-XEM
+			name: "transfer",
+			tx: &base.TransferTransaction{
+				CommonTransaction: testCommon(Transfer, 0x98000001, 4295067296),
+				Amount:            5000000000000,
+				Recipient:         "TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S",
+				Message:           base.Message{Type: 1, Payload: "48656c6c6f"},
+			},
+			want: "0101000001000098c04e230520000000" + testSigner +
+				"a086010001000000d05c230528000000" +
+				"544243493241363755515a414b4352364e53344a574145494345494745494d373247334d56573553" +
+				"005039278c040000" +
+				"0d000000010000000500000048656c6c6f",
+		},
+		{
+			// Mosaic quantity above 2^32
+			name: "mosaic transfer",
+			tx: &base.TransferTransaction{
+				CommonTransaction: testCommon(Transfer, 0x98000002, 50000),
+				Amount:            1000000,
+				Recipient:         "TBCI2A67UQZAKCR6NS4JWAEICEIGEIM72G3MVW5S",
+				Mosaics: []base.Mosaic{{
+					MosaicID: base.MosaicID{NamespaceID: "nem", Name: "xem"},
+					Quantity: 8999999999000000,
+				}},
+			},
+			want: "0101000002000098c04e230520000000" + testSigner +
+				"50c3000000000000d05c230528000000" +
+				"544243493241363755515a414b4352364e53344a574145494345494745494d373247334d56573553" +
+				"40420f0000000000" +
+				"00000000" +
+				"010000001a0000000e000000030000006e656d0300000078656dc03debca73f91f00",
+		},
+		{
+			// Supply delta above 2^32
+			name: "mosaic supply change",
+			tx: &base.MosaicSupplyChangeTransaction{
+				CommonTransaction: testCommon(MosaicSupply, 0x98000001, 150000),
+				MosaicID:          base.MosaicID{NamespaceID: "nem", Name: "xem"},
+				SupplyType:        1,
+				Delta:             1 << 40,
+			},
+			want: "0240000001000098c04e230520000000" + testSigner +
+				"f049020000000000d05c2305" +
+				"0e000000030000006e656d0300000078656d" +
+				"01000000" +
+				"0000000000010000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := SerializeTransaction(tt.tx)
+			if got := hex.EncodeToString(data); got != tt.want {
+				t.Fatalf("SerializeTransaction() =\n%s\nwant\n%s", got, tt.want)
+			}
+
+			tx, err := DeserializeTransaction(data)
+			if err != nil {
+				t.Fatalf("DeserializeTransaction() error = %v", err)
+			}
+			if again := SerializeTransaction(tx); !bytes.Equal(again, data) {
+				t.Fatalf("round trip =\n%x\nwant\n%x", again, data)
+			}
+		})
+	}
+}
+
+// The common header of the test transactions
+func testHeader(txType, version, fee string) string {
+	return txType + version + "c04e2305" + "20000000" + testSigner + fee + "d05c2305"
+}
+
+// The transactions of deserializeVectors encoded by an independent implementation of the NIS binary layout
+// link http://bob.nem.ninja/docs/#transaction-objects
+func TestSerializeTransactionLayout(t *testing.T) {
+	want := map[string]string{
+		"transfer": testHeader("01010000", "01000098", "50c3000000000000") +
+			"28000000544243493241363755515a414b4352364e53344a574145494345494745494d373247334d56573553404b4c00" +
+			"000000000d000000010000000500000048656c6c6f",
+		"mosaic transfer": testHeader("01010000", "02000098", "50c3000000000000") +
+			"28000000544243493241363755515a414b4352364e53344a574145494345494745494d373247334d5657355340420f00" +
+			"0000000000000000020000001a0000000e00000003000000666f6f0300000062617200000000000100001a0000000e00" +
+			"0000030000006e656d0300000078656d0100000000000000",
+		"importance transfer": testHeader("01080000", "01000098", "f049020000000000") +
+			"0200000020000000" +
+			testSigner,
+		"multisig modification": testHeader("01100000", "02000098", "20a1070000000000") +
+			"02000000280000000100000020000000" +
+			testSigner +
+			"280000000200000020000000a1aaca6c17a24252e674d155713cdf55996ad00175be4af02a20c67b59f9fe8a04000000" +
+			"ffffffff",
+		"multisig signature": testHeader("02100000", "01000098", "f049020000000000") +
+			"2400000020000000e1f7b2e1dd6e1f5ad8a4c34e1bc1ba4b41def2b8d44b6b0e5bd7d82a3b6a6a542800000054424349" +
+			"3241363755515a414b4352364e53344a574145494345494745494d373247334d56573553",
+		"multisig": testHeader("04100000", "01000098", "f049020000000000") +
+			"a00000000110000002000098c04e230520000000" +
+			testSigner +
+			"20a1070000000000d05c230502000000280000000100000020000000" +
+			testSigner +
+			"280000000200000020000000a1aaca6c17a24252e674d155713cdf55996ad00175be4af02a20c67b59f9fe8a04000000" +
+			"ffffffff",
+		"root namespace": testHeader("01200000", "01000098", "f049020000000000") +
+			"28000000544243493241363755515a414b4352364e53344a574145494345494745494d373247334d5657355300e1f505" +
+			"0000000003000000666f6fffffffff",
+		"sub namespace": testHeader("01200000", "01000098", "f049020000000000") +
+			"28000000544243493241363755515a414b4352364e53344a574145494345494745494d373247334d5657355380969800" +
+			"000000000300000062617203000000666f6f",
+		"mosaic definition": testHeader("01400000", "01000098", "f049020000000000") +
+			"b900000020000000" +
+			testSigner +
+			"0e00000003000000666f6f030000006261720800000061206d6f7361696304000000150000000c000000646976697369" +
+			"62696c6974790100000033190000000d000000696e697469616c537570706c790400000031303030190000000d000000" +
+			"737570706c794d757461626c650400000074727565180000000c0000007472616e7366657261626c6504000000747275" +
+			"650000000028000000544243493241363755515a414b4352364e53344a574145494345494745494d373247334d565735" +
+			"538096980000000000",
+		"mosaic definition with levy": testHeader("01400000", "01000098", "f049020000000000") +
+			"0301000020000000" +
+			testSigner +
+			"0e00000003000000666f6f030000006261720800000061206d6f7361696304000000150000000c000000646976697369" +
+			"62696c6974790100000033190000000d000000696e697469616c537570706c790400000031303030190000000d000000" +
+			"737570706c794d757461626c650400000074727565180000000c0000007472616e7366657261626c6504000000747275" +
+			"654a0000000100000028000000544243493241363755515a414b4352364e53344a574145494345494745494d37324733" +
+			"4d565735530e000000030000006e656d0300000078656d050000000000000028000000544243493241363755515a414b" +
+			"4352364e53344a574145494345494745494d373247334d565735538096980000000000",
+		"mosaic supply change": testHeader("02400000", "01000098", "f049020000000000") +
+			"0e00000003000000666f6f0300000062617202000000e803000000000000",
+	}
+	vectors := deserializeVectors()
+	if len(vectors) != len(want) {
+		t.Fatalf("%d vectors, want %d", len(vectors), len(want))
+	}
+	for name, tx := range vectors {
+		if got := hex.EncodeToString(SerializeTransaction(tx)); got != want[name] {
+			t.Errorf("%s: SerializeTransaction =\n%s\nwant\n%s", name, got, want[name])
+		}
+	}
+}